@@ -0,0 +1,48 @@
+package anaf
+
+import (
+	"net/http"
+)
+
+type options struct {
+	client *http.Client
+	url    string
+}
+
+type Option interface {
+	apply(opts *options)
+}
+
+var _ Option = optionFunc(nil)
+
+type optionFunc func(opts *options)
+
+func (fn optionFunc) apply(opts *options) {
+	fn(opts)
+}
+
+func WithClient(client *http.Client) Option {
+	return optionFunc(func(opts *options) {
+		opts.client = client
+	})
+}
+
+// WithURL sends the requests to url instead of URL, e.g. to a local stub.
+func WithURL(url string) Option {
+	return optionFunc(func(opts *options) {
+		opts.url = url
+	})
+}
+
+func newOptions(opts []Option) *options {
+	options := &options{
+		client: http.DefaultClient,
+		url:    URL,
+	}
+
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	return options
+}
@@ -0,0 +1,222 @@
+// Package anaf looks up Romanian companies in the public VAT payers registry of the National Agency for Fiscal
+// Administration (ANAF).
+package anaf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const (
+	// URL is the endpoint of the ANAF VAT payers web service.
+	URL = "https://webservicesp.anaf.ro/api/PlatitorTvaRest/v9/tva"
+
+	// MaxBatchSize is the number of companies ANAF accepts in one request.
+	MaxBatchSize = 100
+)
+
+var _ types.CompanyInfoProvider = (*Provider)(nil)
+
+type Provider struct {
+	client *http.Client
+	url    string
+}
+
+func NewProvider(opts ...Option) *Provider {
+	options := newOptions(opts)
+
+	return &Provider{
+		client: options.client,
+		url:    options.url,
+	}
+}
+
+// CompanyInfo looks up a single company, today when date is not set.
+func (p *Provider) CompanyInfo(ctx context.Context, cif string, date types.Date) (types.CompanyInfo, error) {
+	infos, err := p.Lookup(ctx, date, cif)
+	if err != nil {
+		return types.CompanyInfo{}, err
+	}
+
+	return infos[0], nil
+}
+
+// Lookup looks up at most MaxBatchSize companies in one request and returns them in the order of cifs. It
+// fails with an error wrapping types.ErrCompanyNotFound when any of them is unknown.
+func (p *Provider) Lookup(ctx context.Context, date types.Date, cifs ...string) ([]types.CompanyInfo, error) {
+	if len(cifs) == 0 || len(cifs) > MaxBatchSize {
+		return nil, fmt.Errorf("lookup %d companies, want 1 to %d: %w", len(cifs), MaxBatchSize,
+			types.ErrInvalidArgument)
+	}
+
+	if date.IsZero() {
+		date = types.Today()
+	}
+
+	query := make([]request, 0, len(cifs))
+
+	for _, cif := range cifs {
+		if err := types.ValidateCIF(cif); err != nil {
+			return nil, fmt.Errorf("cif: %w", err)
+		}
+
+		code, _ := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(cif)), "RO"), 10, 64)
+		query = append(query, request{CUI: code, Date: date.String()})
+	}
+
+	resp, err := p.do(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]types.CompanyInfo, len(resp.Found))
+
+	for _, company := range resp.Found {
+		found[company.General.CUI] = company.info()
+	}
+
+	infos := make([]types.CompanyInfo, 0, len(query))
+
+	for _, q := range query {
+		info, ok := found[q.CUI]
+		if !ok {
+			return nil, fmt.Errorf("cif %d: %w", q.CUI, types.ErrCompanyNotFound)
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func (p *Provider) do(ctx context.Context, query []request) (*response, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", httpResp.Status)
+	}
+
+	resp := &response{}
+
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if resp.Code != http.StatusOK {
+		return nil, fmt.Errorf("anaf error %d: %s", resp.Code, resp.Message)
+	}
+
+	return resp, nil
+}
+
+type request struct {
+	CUI  int64  `json:"cui"`
+	Date string `json:"data"`
+}
+
+type response struct {
+	Code     int       `json:"cod"`
+	Message  string    `json:"message"`
+	Found    []company `json:"found"`
+	NotFound []int64   `json:"notFound"`
+}
+
+type company struct {
+	General struct {
+		CUI        int64  `json:"cui"`
+		Date       string `json:"data"`
+		Name       string `json:"denumire"`
+		Address    string `json:"adresa"`
+		RC         string `json:"nrRegCom"`
+		Phone      string `json:"telefon"`
+		PostalCode string `json:"codPostal"`
+		EInvoice   bool   `json:"statusRO_e_Factura"`
+	} `json:"date_generale"`
+	VAT struct {
+		Registered bool `json:"scpTVA"`
+	} `json:"inregistrare_scop_Tva"`
+	VATOnCollection struct {
+		Status bool `json:"statusTvaIncasare"`
+	} `json:"inregistrare_RTVAI"`
+	Inactive struct {
+		Status         bool   `json:"statusInactivi"`
+		DeregisteredOn string `json:"dataRadiere"`
+	} `json:"stare_inactiv"`
+	Office struct {
+		Street     string `json:"sdenumire_Strada"`
+		Number     string `json:"snumar_Strada"`
+		City       string `json:"sdenumire_Localitate"`
+		County     string `json:"sdenumire_Judet"`
+		Details    string `json:"sdetalii_Adresa"`
+		PostalCode string `json:"scod_Postal"`
+	} `json:"adresa_sediu_social"`
+}
+
+func (c company) info() types.CompanyInfo {
+	info := types.CompanyInfo{
+		CIF:             strconv.FormatInt(c.General.CUI, 10),
+		Name:            c.General.Name,
+		RC:              c.General.RC,
+		Address:         c.General.Address,
+		City:            c.Office.City,
+		County:          c.Office.County,
+		PostalCode:      c.Office.PostalCode,
+		Phone:           c.General.Phone,
+		Active:          !c.Inactive.Status && c.Inactive.DeregisteredOn == "",
+		VATPayer:        c.VAT.Registered,
+		VATOnCollection: c.VATOnCollection.Status,
+		EInvoice:        c.General.EInvoice,
+	}
+
+	if street := strings.TrimSpace(c.Office.Street); street != "" {
+		parts := []string{street}
+
+		if number := strings.TrimSpace(c.Office.Number); number != "" {
+			parts = append(parts, "nr. "+number)
+		}
+
+		if details := strings.TrimSpace(c.Office.Details); details != "" {
+			parts = append(parts, details)
+		}
+
+		info.Address = strings.Join(parts, ", ")
+	}
+
+	if county, ok := types.LookupCounty(c.Office.County); ok {
+		info.County = county.Name
+	}
+
+	if info.PostalCode == "" {
+		info.PostalCode = c.General.PostalCode
+	}
+
+	if t, err := time.Parse(time.DateOnly, c.General.Date); err == nil {
+		info.Date = types.NewDate(t.Year(), int(t.Month()), t.Day())
+	}
+
+	return info
+}
@@ -0,0 +1,102 @@
+package anaf_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/anaf"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func newServer(t *testing.T, file string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query []map[string]any
+
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		http.ServeFile(w, r, file)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProvider_Lookup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := newServer(t, "testdata/response.json")
+	provider := anaf.NewProvider(anaf.WithURL(srv.URL), anaf.WithClient(srv.Client()))
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := provider.Lookup(ctx, types.NewDate(2024, 5, 7), "12345674", "RO37311090")
+		require.NoError(t, err)
+		require.Equal(t, []types.CompanyInfo{
+			{
+				CIF:             "12345674",
+				Name:            "FIRMA INACTIVA SRL",
+				RC:              "J12/100/2000",
+				Address:         "JUD. CLUJ, MUN. CLUJ-NAPOCA, STR. REPUBLICII, NR.1",
+				City:            "Mun. Cluj-Napoca",
+				County:          "Cluj",
+				PostalCode:      "400015",
+				VATOnCollection: true,
+				Date:            types.NewDate(2024, 5, 7),
+			},
+			{
+				CIF:        "37311090",
+				Name:       "OBLIO SOFTWARE SRL",
+				RC:         "J40/4090/2017",
+				Address:    "Str. Constantin Brâncuşi, nr. 133, BL.D2, SC.1, ET.1, AP.6",
+				City:       "Sector 3 Mun. Bucureşti",
+				County:     "București",
+				PostalCode: "032103",
+				Phone:      "0723121111",
+				Active:     true,
+				VATPayer:   true,
+				EInvoice:   true,
+				Date:       types.NewDate(2024, 5, 7),
+			},
+		}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := provider.CompanyInfo(ctx, "19", types.NewDate(2024, 5, 7))
+		require.ErrorIs(t, err, types.ErrCompanyNotFound)
+	})
+
+	t.Run("invalid cif", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := provider.CompanyInfo(ctx, "13", types.Date{})
+		require.ErrorIs(t, err, types.ErrInvalidArgument)
+	})
+}
+
+func TestProvider_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"cod":404,"message":"Numarul maxim de CUI-uri a fost depasit"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := anaf.NewProvider(anaf.WithURL(srv.URL))
+
+	_, err := provider.CompanyInfo(context.Background(), "RO37311090", types.Date{})
+	require.ErrorContains(t, err, "anaf error 404: Numarul maxim de CUI-uri a fost depasit")
+}
@@ -0,0 +1,61 @@
+package oblio
+
+import (
+	"context"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+var _ API = (*Client)(nil)
+
+// API is the set of operations exposed by Client. Depend on it instead of *Client to swap in a test double
+// such as the one from the obliomock package.
+type API interface {
+	GenerateToken(ctx context.Context) (*GenerateTokenResponse, error)
+
+	GetCompanies(ctx context.Context, req *GetCompaniesRequest) (*GetCompaniesResponse, error)
+	GetVATRates(ctx context.Context, req *GetVATRatesRequest) (*GetVATRatesResponse, error)
+	GetClients(ctx context.Context, req *GetClientsRequest) (*GetClientsResponse, error)
+	Clients(ctx context.Context, filter *GetClientsRequest, opts ...IteratorOption) *Iterator[types.Client]
+	StreamClients(
+		ctx context.Context, req *GetClientsRequest, fn func(client types.Client) error,
+	) (*StreamResponse, error)
+	ResolveClient(ctx context.Context, req *ResolveClientRequest) (*ResolveClientResponse, error)
+	UpsertClient(ctx context.Context, req *ResolveClientRequest) (*ResolveClientResponse, error)
+	GetProducts(ctx context.Context, req *GetProductsRequest) (*GetProductsResponse, error)
+	Products(ctx context.Context, filter *GetProductsRequest, opts ...IteratorOption) *Iterator[types.Product]
+	StreamProducts(
+		ctx context.Context, req *GetProductsRequest, fn func(product types.Product) error,
+	) (*StreamResponse, error)
+	GetSeries(ctx context.Context, req *GetSeriesRequest) (*GetSeriesResponse, error)
+	GetLanguages(ctx context.Context, req *GetLanguagesRequest) (*GetLanguagesResponse, error)
+	GetManagement(ctx context.Context, req *GetManagementRequest) (*GetManagementResponse, error)
+	InvalidateNomenclature(ctx context.Context, cif string) error
+	WarmupNomenclature(ctx context.Context, cif string) error
+	Preflight(ctx context.Context, req CreateDocumentRequest) error
+
+	CreateInvoice(ctx context.Context, req *CreateInvoiceRequest) (*CreateInvoiceResponse, error)
+	GetInvoice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	GetInvoices(ctx context.Context, req *GetInvoicesRequest) (*GetInvoicesResponse, error)
+	Invoices(ctx context.Context, filter *GetInvoicesRequest, opts ...IteratorOption) *Iterator[types.Invoice]
+	StreamInvoices(
+		ctx context.Context, req *GetInvoicesRequest, fn func(invoice types.Invoice) error,
+	) (*StreamResponse, error)
+	ListInvoices(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error)
+	CancelInvoice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	RestoreInvoice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	DeleteInvoice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	Collect(ctx context.Context, req *CollectRequest) (*CollectResponse, error)
+
+	CreateProforma(ctx context.Context, req *CreateProformaRequest) (*CreateProformaResponse, error)
+	GetProforma(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	CancelProforma(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	RestoreProforma(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	DeleteProforma(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+
+	CreateNotice(ctx context.Context, req *CreateNoticeRequest) (*CreateNoticeResponse, error)
+	GetNotice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	CancelNotice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	RestoreNotice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+	DeleteNotice(ctx context.Context, req *DocumentRequest) (*DocumentResponse, error)
+}
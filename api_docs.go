@@ -2,6 +2,7 @@ package oblio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/vcraescu/go-oblio-api/types"
@@ -21,6 +22,10 @@ type DocumentRequest struct {
 	Number     string `json:"number,omitempty" url:"number,omitempty"`
 }
 
+func (r *DocumentRequest) Validate() error {
+	return errors.Join(validateDocumentRequest(r.CIF, r.SeriesName, r.Number)...)
+}
+
 type DocumentResponse struct {
 	Status
 
@@ -2,23 +2,27 @@ package oblio
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/vcraescu/go-oblio-api/types"
 )
 
 type CreateInvoiceRequest struct {
-	CIF                string                  `json:"cif,omitempty"`
-	Client             types.Client            `json:"client,omitempty"`
-	IssueDate          types.Date              `json:"issueDate,omitempty"`
-	DueDate            types.Date              `json:"dueDate,omitempty"`
+	CIF       string       `json:"cif,omitempty"`
+	Client    types.Client `json:"client,omitempty"`
+	IssueDate types.Date   `json:"issueDate,omitempty"`
+	DueDate   types.Date   `json:"dueDate,omitempty"`
+	// PaymentTerms, when set, check DueDate or fill it in when empty. They are not sent to Oblio.
+	PaymentTerms       *types.PaymentTerms     `json:"-"`
 	DeliveryDate       types.Date              `json:"deliveryDate,omitempty"`
 	CollectDate        types.Date              `json:"collectDate,omitempty"`
 	SeriesName         string                  `json:"seriesName,omitempty"`
 	Language           string                  `json:"language,omitempty"`
 	Precision          types.Int               `json:"precision,omitempty"`
 	Currency           string                  `json:"currency,omitempty"`
-	ExchangeRate       string                  `json:"exchangeRate,omitempty"`
+	ExchangeRate       types.Decimal           `json:"exchangeRate,omitempty"`
 	Products           []types.DocumentRow     `json:"products,omitempty"`
 	IssuerName         string                  `json:"issuerName,omitempty"`
 	IssuerID           string                  `json:"issuerId,omitempty"`
@@ -36,6 +40,42 @@ type CreateInvoiceRequest struct {
 	UseStock           types.Bool              `json:"useStock,omitempty"`
 }
 
+func (r *CreateInvoiceRequest) documentFields() documentFields {
+	return documentFields{
+		cif:          r.CIF,
+		seriesName:   r.SeriesName,
+		client:       r.Client,
+		issueDate:    r.IssueDate,
+		dueDate:      r.DueDate,
+		paymentTerms: r.PaymentTerms,
+		currency:     r.Currency,
+		exchangeRate: r.ExchangeRate,
+		products:     r.Products,
+		language:     r.Language,
+		workStation:  r.WorkStation,
+		seriesType:   types.InvoiceDocumentType,
+		issuerID:     r.IssuerID,
+	}
+}
+
+func (r *CreateInvoiceRequest) setExchangeRate(rate types.Decimal) {
+	r.ExchangeRate = rate
+}
+
+func (r *CreateInvoiceRequest) setDueDate(date types.Date) {
+	r.DueDate = date
+}
+
+func (r *CreateInvoiceRequest) Validate() error {
+	errs := r.documentFields().validate()
+
+	if r.Collect != (types.Collect{}) {
+		errs = append(errs, fieldErrors("collect", r.Collect.Validate())...)
+	}
+
+	return errors.Join(errs...)
+}
+
 type CreateInvoiceResponse struct {
 	Status
 
@@ -43,6 +83,19 @@ type CreateInvoiceResponse struct {
 }
 
 func (c *Client) CreateInvoice(ctx context.Context, req *CreateInvoiceRequest) (*CreateInvoiceResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := withExchangeRate(ctx, c, withDueDate(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.preflight(ctx, req); err != nil {
+		return nil, err
+	}
+
 	resp := &CreateInvoiceResponse{}
 
 	if err := c.callDocsAPI(ctx, http.MethodPost, "/invoice", req, resp); err != nil {
@@ -59,6 +112,20 @@ type CollectRequest struct {
 	Collects   []types.Collect `json:"collects,omitempty"`
 }
 
+func (r *CollectRequest) Validate() error {
+	errs := validateDocumentRequest(r.CIF, r.SeriesName, r.Number)
+
+	if len(r.Collects) == 0 {
+		errs = append(errs, fmt.Errorf("collects is empty: %w", ErrInvalidArgument))
+	}
+
+	for i := range r.Collects {
+		errs = append(errs, fieldErrors(fmt.Sprintf("collects[%d]", i), r.Collects[i].Validate())...)
+	}
+
+	return errors.Join(errs...)
+}
+
 type CollectResponse struct {
 	Status
 
@@ -154,6 +221,40 @@ type GetInvoicesRequest struct {
 	Offset             int          `json:"offset,omitempty" url:"offset,omitempty"`
 }
 
+func (r *GetInvoicesRequest) Validate() error {
+	var errs []error
+
+	if r.CIF == "" {
+		errs = append(errs, fmt.Errorf("cif is empty: %w", ErrInvalidArgument))
+	}
+
+	if !r.IssuedAfter.IsZero() && !r.IssuedBefore.IsZero() && r.IssuedBefore.Before(r.IssuedAfter) {
+		errs = append(errs, fmt.Errorf("issuedBefore is before issuedAfter: %w", ErrInvalidArgument))
+	}
+
+	switch r.OrderBy {
+	case "", IDOrderBy, IssueDateOrderBy, NumberOrderBy:
+	default:
+		errs = append(errs, fmt.Errorf("orderBy %q is unknown: %w", r.OrderBy, ErrInvalidArgument))
+	}
+
+	switch r.OrderDir {
+	case "", AscOrderDir, DescOrderDir:
+	default:
+		errs = append(errs, fmt.Errorf("orderDir %q is unknown: %w", r.OrderDir, ErrInvalidArgument))
+	}
+
+	if r.LimitPerPage < 0 || r.LimitPerPage > MaxLimitPerPage {
+		errs = append(errs, fmt.Errorf("limitPerPage %d is out of range: %w", r.LimitPerPage, ErrInvalidArgument))
+	}
+
+	if r.Offset < 0 {
+		errs = append(errs, fmt.Errorf("offset %d is negative: %w", r.Offset, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
 type GetInvoicesResponse struct {
 	Status
 
@@ -2,21 +2,24 @@ package oblio
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/vcraescu/go-oblio-api/types"
 )
 
 type CreateNoticeRequest struct {
-	CIF                string              `json:"cif,omitempty"`
-	Client             types.Client        `json:"client,omitempty"`
-	IssueDate          types.Date          `json:"issueDate,omitempty"`
-	DueDate            types.Date          `json:"dueDate,omitempty"`
+	CIF       string       `json:"cif,omitempty"`
+	Client    types.Client `json:"client,omitempty"`
+	IssueDate types.Date   `json:"issueDate,omitempty"`
+	DueDate   types.Date   `json:"dueDate,omitempty"`
+	// PaymentTerms, when set, check DueDate or fill it in when empty. They are not sent to Oblio.
+	PaymentTerms       *types.PaymentTerms `json:"-"`
 	SeriesName         string              `json:"seriesName,omitempty"`
 	Language           string              `json:"language,omitempty"`
 	Precision          types.Int           `json:"precision,omitempty"`
 	Currency           string              `json:"currency,omitempty"`
-	ExchangeRate       int                 `json:"exchangeRate,omitempty"`
+	ExchangeRate       types.Decimal       `json:"exchangeRate,omitempty"`
 	Products           []types.DocumentRow `json:"products,omitempty"`
 	IssuerName         string              `json:"issuerName,omitempty"`
 	IssuerID           int64               `json:"issuerId,omitempty"`
@@ -31,6 +34,38 @@ type CreateNoticeRequest struct {
 	UseStock           types.Bool          `json:"useStock"`
 }
 
+func (r *CreateNoticeRequest) documentFields() documentFields {
+	return documentFields{
+		cif:          r.CIF,
+		seriesName:   r.SeriesName,
+		client:       r.Client,
+		issueDate:    r.IssueDate,
+		dueDate:      r.DueDate,
+		paymentTerms: r.PaymentTerms,
+		currency:     r.Currency,
+		exchangeRate: r.ExchangeRate,
+		products:     r.Products,
+		language:     r.Language,
+		workStation:  r.WorkStation,
+		seriesType:   types.NoticeDocumentType,
+		issuerID:     formatIssuerID(r.IssuerID),
+	}
+}
+
+func (r *CreateNoticeRequest) setExchangeRate(rate types.Decimal) {
+	r.ExchangeRate = rate
+}
+
+func (r *CreateNoticeRequest) setDueDate(date types.Date) {
+	r.DueDate = date
+}
+
+func (r *CreateNoticeRequest) Validate() error {
+	errs := r.documentFields().validate()
+
+	return errors.Join(errs...)
+}
+
 type CreateNoticeResponse struct {
 	Status
 
@@ -38,6 +73,19 @@ type CreateNoticeResponse struct {
 }
 
 func (c *Client) CreateNotice(ctx context.Context, req *CreateNoticeRequest) (*CreateNoticeResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := withExchangeRate(ctx, c, withDueDate(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.preflight(ctx, req); err != nil {
+		return nil, err
+	}
+
 	resp := &CreateNoticeResponse{}
 
 	if err := c.callDocsAPI(ctx, http.MethodPost, "/notice", req, resp); err != nil {
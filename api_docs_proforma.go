@@ -2,21 +2,24 @@ package oblio
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/vcraescu/go-oblio-api/types"
 )
 
 type CreateProformaRequest struct {
-	CIF                string              `json:"cif,omitempty"`
-	Client             types.Client        `json:"client,omitempty"`
-	IssueDate          types.Date          `json:"issueDate,omitempty"`
-	DueDate            types.Date          `json:"dueDate,omitempty"`
+	CIF       string       `json:"cif,omitempty"`
+	Client    types.Client `json:"client,omitempty"`
+	IssueDate types.Date   `json:"issueDate,omitempty"`
+	DueDate   types.Date   `json:"dueDate,omitempty"`
+	// PaymentTerms, when set, check DueDate or fill it in when empty. They are not sent to Oblio.
+	PaymentTerms       *types.PaymentTerms `json:"-"`
 	SeriesName         string              `json:"seriesName,omitempty"`
 	Language           string              `json:"language,omitempty"`
 	Precision          types.Int           `json:"precision,omitempty"`
 	Currency           string              `json:"currency,omitempty"`
-	ExchangeRate       int                 `json:"exchangeRate,omitempty"`
+	ExchangeRate       types.Decimal       `json:"exchangeRate,omitempty"`
 	Products           []types.DocumentRow `json:"products,omitempty"`
 	IssuerName         string              `json:"issuerName,omitempty"`
 	IssuerID           int64               `json:"issuerId,omitempty"`
@@ -31,6 +34,38 @@ type CreateProformaRequest struct {
 	SendEmail          bool                `json:"sendEmail,omitempty"`
 }
 
+func (r *CreateProformaRequest) documentFields() documentFields {
+	return documentFields{
+		cif:          r.CIF,
+		seriesName:   r.SeriesName,
+		client:       r.Client,
+		issueDate:    r.IssueDate,
+		dueDate:      r.DueDate,
+		paymentTerms: r.PaymentTerms,
+		currency:     r.Currency,
+		exchangeRate: r.ExchangeRate,
+		products:     r.Products,
+		language:     r.Language,
+		workStation:  r.WorkStation,
+		seriesType:   types.ProformaDocumentType,
+		issuerID:     formatIssuerID(r.IssuerID),
+	}
+}
+
+func (r *CreateProformaRequest) setExchangeRate(rate types.Decimal) {
+	r.ExchangeRate = rate
+}
+
+func (r *CreateProformaRequest) setDueDate(date types.Date) {
+	r.DueDate = date
+}
+
+func (r *CreateProformaRequest) Validate() error {
+	errs := r.documentFields().validate()
+
+	return errors.Join(errs...)
+}
+
 type CreateProformaResponse struct {
 	Status
 
@@ -38,6 +73,19 @@ type CreateProformaResponse struct {
 }
 
 func (c *Client) CreateProforma(ctx context.Context, req *CreateProformaRequest) (*CreateProformaResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	req, err := withExchangeRate(ctx, c, withDueDate(req))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.preflight(ctx, req); err != nil {
+		return nil, err
+	}
+
 	resp := &CreateProformaResponse{}
 
 	if err := c.callDocsAPI(ctx, http.MethodPost, "/proforma", req, resp); err != nil {
@@ -8,8 +8,12 @@ import (
 	"github.com/vcraescu/go-oblio-api/types"
 )
 
-func (c *Client) callNomenclatureAPI(ctx context.Context, endpointSuffix string, req, resp any) error {
-	if err := c.callAPI(ctx, http.MethodGet, "/nomenclature", endpointSuffix, req, resp); err != nil {
+func (c *Client) callNomenclatureAPI(ctx context.Context, endpoint NomenclatureEndpoint, req, resp any) error {
+	if ttl, ok := c.cache.ttl(endpoint); ok {
+		return c.callCachedNomenclatureAPI(ctx, endpoint, ttl, req, resp)
+	}
+
+	if err := c.callAPI(ctx, http.MethodGet, "/nomenclature", string(endpoint), req, resp); err != nil {
 		return fmt.Errorf("callAPI: %w", err)
 	}
 
@@ -29,7 +33,7 @@ type GetCompaniesResponse struct {
 func (c *Client) GetCompanies(ctx context.Context, req *GetCompaniesRequest) (*GetCompaniesResponse, error) {
 	resp := &GetCompaniesResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "companies", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, CompaniesEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -51,7 +55,7 @@ type GetVATRatesResponse struct {
 func (c *Client) GetVATRates(ctx context.Context, req *GetVATRatesRequest) (*GetVATRatesResponse, error) {
 	resp := &GetVATRatesResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "vat_rates", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, VATRatesEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -76,7 +80,7 @@ type GetClientsResponse struct {
 func (c *Client) GetClients(ctx context.Context, req *GetClientsRequest) (*GetClientsResponse, error) {
 	resp := &GetClientsResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "clients", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, ClientsEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -103,7 +107,7 @@ type GetProductsResponse struct {
 func (c *Client) GetProducts(ctx context.Context, req *GetProductsRequest) (*GetProductsResponse, error) {
 	resp := &GetProductsResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "products", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, ProductsEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -125,7 +129,7 @@ type GetSeriesResponse struct {
 func (c *Client) GetSeries(ctx context.Context, req *GetSeriesRequest) (*GetSeriesResponse, error) {
 	resp := &GetSeriesResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "series", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, SeriesEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -147,7 +151,7 @@ type GetLanguagesResponse struct {
 func (c *Client) GetLanguages(ctx context.Context, req *GetLanguagesRequest) (*GetLanguagesResponse, error) {
 	resp := &GetLanguagesResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "languages", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, LanguagesEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
@@ -169,7 +173,7 @@ type GetManagementResponse struct {
 func (c *Client) GetManagement(ctx context.Context, req *GetManagementRequest) (*GetManagementResponse, error) {
 	resp := &GetManagementResponse{}
 
-	if err := c.callNomenclatureAPI(ctx, "management", req, resp); err != nil {
+	if err := c.callNomenclatureAPI(ctx, ManagementEndpoint, req, resp); err != nil {
 		return nil, err
 	}
 
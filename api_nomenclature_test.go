@@ -151,12 +151,12 @@ func TestClient_GetVATRates(t *testing.T) {
 				Data: []types.VATRate{
 					{
 						Name:    "Normala",
-						Percent: 19,
+						Percent: "19",
 						Default: true,
 					},
 					{
 						Name:    "Redusa",
-						Percent: 9,
+						Percent: "9",
 						Default: false,
 					},
 				},
@@ -296,7 +296,7 @@ func TestClient_GetProducts(t *testing.T) {
 						Price:         "119.00",
 						Currency:      "RON",
 						VATName:       "Normala",
-						VATPercentage: 19,
+						VATPercentage: "19",
 						VATIncluded:   true,
 					},
 					{
@@ -308,11 +308,11 @@ func TestClient_GetProducts(t *testing.T) {
 							{
 								WorkStation:   "Sediu",
 								Management:    "Magazin",
-								Quantity:      2,
+								Quantity:      "2",
 								Price:         "200.00",
 								Currency:      "RON",
 								VATName:       "Normala",
-								VATPercentage: 19,
+								VATPercentage: "19",
 							},
 						},
 					},
@@ -0,0 +1,76 @@
+package bnr
+
+import (
+	"net/http"
+	"time"
+)
+
+type options struct {
+	client       *http.Client
+	source       string
+	file         bool
+	maxFallback  int
+	refreshAfter time.Duration
+}
+
+type Option interface {
+	apply(opts *options)
+}
+
+var _ Option = optionFunc(nil)
+
+type optionFunc func(opts *options)
+
+func (fn optionFunc) apply(opts *options) {
+	fn(opts)
+}
+
+func WithClient(client *http.Client) Option {
+	return optionFunc(func(opts *options) {
+		opts.client = client
+	})
+}
+
+// WithURL loads the feed from url instead of YearlyURL. A {year} placeholder is replaced with the year of the
+// requested date; without it the same feed, e.g. the ten days one, serves every date.
+func WithURL(url string) Option {
+	return optionFunc(func(opts *options) {
+		opts.source = url
+		opts.file = false
+	})
+}
+
+// WithFile loads the feed from a local file, path accepting the same {year} placeholder as WithURL.
+func WithFile(path string) Option {
+	return optionFunc(func(opts *options) {
+		opts.source = path
+		opts.file = true
+	})
+}
+
+func WithMaxFallback(days int) Option {
+	return optionFunc(func(opts *options) {
+		opts.maxFallback = days
+	})
+}
+
+func WithRefreshAfter(d time.Duration) Option {
+	return optionFunc(func(opts *options) {
+		opts.refreshAfter = d
+	})
+}
+
+func newOptions(opts []Option) *options {
+	options := &options{
+		client:       http.DefaultClient,
+		source:       YearlyURL,
+		maxFallback:  DefaultMaxFallback,
+		refreshAfter: DefaultRefreshAfter,
+	}
+
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	return options
+}
@@ -0,0 +1,273 @@
+// Package bnr provides the reference exchange rates published daily by the National Bank of Romania (BNR).
+package bnr
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const (
+	// YearlyURL is the BNR feed holding every publication of a year, {year} being replaced with the year.
+	YearlyURL = "https://www.bnr.ro/files/xml/years/nbrfxrates{year}.xml"
+
+	// DefaultMaxFallback covers the longest run of days without a publication, e.g. Christmas followed by a
+	// weekend.
+	DefaultMaxFallback = 10
+
+	// DefaultRefreshAfter is how long a loaded feed is trusted before a rate newer than its last publication
+	// triggers a reload.
+	DefaultRefreshAfter = time.Hour
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// Publication is the set of reference rates published on a day, in RON for one unit of each currency.
+type Publication struct {
+	Date  types.Date
+	Rates map[string]types.Decimal
+}
+
+type feed struct {
+	publications []Publication
+	loadedAt     time.Time
+}
+
+// Provider looks up BNR reference rates. Feeds are loaded on first use and kept in memory, a feed being
+// loaded again only when a rate newer than its last publication is asked for after RefreshAfter. It is safe
+// for concurrent use.
+type Provider struct {
+	source       string
+	load         func(ctx context.Context, source string) (io.ReadCloser, error)
+	maxFallback  int
+	refreshAfter time.Duration
+	now          func() time.Time
+	mu           sync.Mutex
+	feeds        map[string]*feed
+}
+
+func NewProvider(opts ...Option) *Provider {
+	options := newOptions(opts)
+
+	p := &Provider{
+		source:       options.source,
+		maxFallback:  options.maxFallback,
+		refreshAfter: options.refreshAfter,
+		now:          time.Now,
+		feeds:        make(map[string]*feed),
+	}
+
+	if options.file {
+		p.load = loadFile
+	} else {
+		p.load = func(ctx context.Context, source string) (io.ReadCloser, error) {
+			return loadURL(ctx, options.client, source)
+		}
+	}
+
+	return p
+}
+
+// ExchangeRate returns the RON value of one unit of currency on date. Days without a publication, such as
+// weekends and public holidays, use the last rate published before them.
+func (p *Provider) ExchangeRate(ctx context.Context, currency string, date types.Date) (types.Decimal, error) {
+	pub, err := p.Publication(ctx, date)
+	if err != nil {
+		return "", err
+	}
+
+	currency = strings.ToUpper(currency)
+
+	if currency == "RON" {
+		return types.NewDecimalFromInt(1), nil
+	}
+
+	rate, ok := pub.Rates[currency]
+	if !ok {
+		return "", fmt.Errorf("%s on %s: %w", currency, pub.Date, ErrRateNotFound)
+	}
+
+	return rate, nil
+}
+
+// Publication returns the last publication made on or before date, looking back at most MaxFallback days.
+func (p *Provider) Publication(ctx context.Context, date types.Date) (Publication, error) {
+	if date.IsZero() {
+		date = types.Today()
+	}
+
+	earliest := date.AddDays(-p.maxFallback)
+
+	for year := date.Year(); year >= earliest.Year(); year-- {
+		pubs, err := p.publications(ctx, year, date)
+		if err != nil {
+			return Publication{}, err
+		}
+
+		i, found := slices.BinarySearchFunc(pubs, date, func(pub Publication, d types.Date) int {
+			return pub.Date.Compare(d)
+		})
+		if !found {
+			i--
+		}
+
+		if i >= 0 && !pubs[i].Date.Before(earliest) {
+			return pubs[i], nil
+		}
+
+		if i >= 0 {
+			break
+		}
+	}
+
+	return Publication{}, fmt.Errorf("no publication between %s and %s: %w", earliest, date, ErrRateNotFound)
+}
+
+func (p *Provider) publications(ctx context.Context, year int, date types.Date) ([]Publication, error) {
+	source := strings.ReplaceAll(p.source, "{year}", strconv.Itoa(year))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if f, ok := p.feeds[source]; ok && !p.stale(f, date) {
+		return f.publications, nil
+	}
+
+	body, err := p.load(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", source, err)
+	}
+	defer body.Close()
+
+	pubs, err := Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", source, err)
+	}
+
+	p.feeds[source] = &feed{publications: pubs, loadedAt: p.now()}
+
+	return pubs, nil
+}
+
+// stale reports whether a publication for date may have been made after the feed was loaded.
+func (p *Provider) stale(f *feed, date types.Date) bool {
+	if len(f.publications) > 0 && !f.publications[len(f.publications)-1].Date.Before(date) {
+		return false
+	}
+
+	if types.DateOf(f.loadedAt).After(date) {
+		return false
+	}
+
+	return p.now().Sub(f.loadedAt) >= p.refreshAfter
+}
+
+type dataSet struct {
+	Cubes []struct {
+		Date  string `xml:"date,attr"`
+		Rates []struct {
+			Currency   string `xml:"currency,attr"`
+			Multiplier string `xml:"multiplier,attr"`
+			Value      string `xml:",chardata"`
+		} `xml:"Rate"`
+	} `xml:"Body>Cube"`
+}
+
+// Parse reads a BNR feed, daily, for the last ten days or for a whole year, and returns its publications
+// sorted by date. Rates quoted for 100 units, such as HUF, are converted to one unit.
+func Parse(r io.Reader) ([]Publication, error) {
+	var ds dataSet
+
+	if err := xml.NewDecoder(r).Decode(&ds); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	pubs := make([]Publication, 0, len(ds.Cubes))
+
+	for _, cube := range ds.Cubes {
+		t, err := time.Parse(time.DateOnly, cube.Date)
+		if err != nil {
+			return nil, fmt.Errorf("cube date %q: %w", cube.Date, err)
+		}
+
+		pub := Publication{
+			Date:  types.NewDate(t.Year(), int(t.Month()), t.Day()),
+			Rates: make(map[string]types.Decimal, len(cube.Rates)),
+		}
+
+		for _, rate := range cube.Rates {
+			value, err := parseRate(rate.Value, rate.Multiplier)
+			if err != nil {
+				return nil, fmt.Errorf("%s rate on %s: %w", rate.Currency, cube.Date, err)
+			}
+
+			pub.Rates[strings.ToUpper(rate.Currency)] = value
+		}
+
+		pubs = append(pubs, pub)
+	}
+
+	slices.SortFunc(pubs, func(a, b Publication) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return pubs, nil
+}
+
+func parseRate(value, multiplier string) (types.Decimal, error) {
+	rate, err := types.ParseDecimal(value)
+	if err != nil {
+		return "", err
+	}
+
+	if multiplier == "" || multiplier == "1" {
+		return rate, nil
+	}
+
+	m, err := strconv.Atoi(multiplier)
+	if err != nil || m <= 0 {
+		return "", fmt.Errorf("invalid multiplier: %q", multiplier)
+	}
+
+	scale := 0
+	if i := strings.IndexByte(string(rate), '.'); i >= 0 {
+		scale = len(rate) - i - 1
+	}
+
+	return rate.Div(types.NewDecimalFromInt(int64(m)), scale+len(multiplier)-1)
+}
+
+func loadURL(ctx context.Context, client *http.Client, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func loadFile(_ context.Context, path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
@@ -0,0 +1,144 @@
+package bnr_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/bnr"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestProvider_ExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	provider := bnr.NewProvider(bnr.WithFile("testdata/nbrfxrates{year}.xml"))
+
+	tests := []struct {
+		name     string
+		currency string
+		date     types.Date
+		want     types.Decimal
+		wantErr  error
+	}{
+		{
+			name:     "publication day",
+			currency: "EUR",
+			date:     types.NewDate(2024, 1, 4),
+			want:     "4.9723",
+		},
+		{
+			name:     "weekend",
+			currency: "usd",
+			date:     types.NewDate(2024, 1, 7),
+			want:     "4.5489",
+		},
+		{
+			name:     "easter holidays",
+			currency: "EUR",
+			date:     types.NewDate(2024, 5, 6),
+			want:     "4.9765",
+		},
+		{
+			name:     "new year falls back to previous year",
+			currency: "EUR",
+			date:     types.NewDate(2024, 1, 2),
+			want:     "4.9746",
+		},
+		{
+			name:     "multiplier",
+			currency: "HUF",
+			date:     types.NewDate(2024, 5, 7),
+			want:     "0.012834",
+		},
+		{
+			name:     "ron",
+			currency: "RON",
+			date:     types.NewDate(2024, 5, 7),
+			want:     "1",
+		},
+		{
+			name:     "unknown currency",
+			currency: "XYZ",
+			date:     types.NewDate(2024, 5, 7),
+			wantErr:  bnr.ErrRateNotFound,
+		},
+		{
+			name:     "fallback too long",
+			currency: "EUR",
+			date:     types.NewDate(2024, 3, 1),
+			wantErr:  bnr.ErrRateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := provider.ExchangeRate(context.Background(), tt.currency, tt.date)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProvider_Cache(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.ServeFile(w, r, "testdata/nbrfxrates2024.xml")
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := bnr.NewProvider(bnr.WithURL(srv.URL+"/nbrfxrates10days.xml"), bnr.WithClient(srv.Client()))
+
+	for _, date := range []types.Date{types.NewDate(2024, 1, 3), types.NewDate(2024, 1, 5), types.NewDate(2024, 5, 3)} {
+		_, err := provider.ExchangeRate(context.Background(), "EUR", date)
+		require.NoError(t, err)
+	}
+
+	require.EqualValues(t, 1, requests.Load())
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	got, err := bnr.Parse(strings.NewReader(`<DataSet><Body><Cube date="2024-05-07"><Rate currency="JPY" multiplier="100">2.9824</Rate></Cube></Body></DataSet>`))
+	require.NoError(t, err)
+	require.Equal(t, []bnr.Publication{
+		{Date: types.NewDate(2024, 5, 7), Rates: map[string]types.Decimal{"JPY": "0.029824"}},
+	}, got)
+
+	_, err = bnr.Parse(strings.NewReader(`<DataSet><Body><Cube date="2024-05-07"><Rate currency="EUR">x</Rate></Cube></Body></DataSet>`))
+	require.ErrorContains(t, err, "EUR rate on 2024-05-07")
+}
+
+// TestProvider_ExchangeRate_BusinessLocation is not parallel as it changes the business location.
+func TestProvider_ExchangeRate_BusinessLocation(t *testing.T) {
+	loc := types.BusinessLocation()
+	t.Cleanup(func() {
+		types.SetBusinessLocation(loc)
+	})
+
+	types.SetBusinessLocation(time.FixedZone("EST", -5*60*60))
+
+	got, err := bnr.NewProvider(bnr.WithFile("testdata/nbrfxrates{year}.xml")).
+		ExchangeRate(context.Background(), "EUR", types.NewDate(2024, 1, 4))
+	require.NoError(t, err)
+	require.Equal(t, types.Decimal("4.9723"), got)
+}
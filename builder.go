@@ -0,0 +1,435 @@
+package oblio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+// DocumentBuilder describes an invoice, proforma or notice fluently and produces the matching create request.
+// Problems are collected along the way and reported by the Build methods.
+type DocumentBuilder struct {
+	cif                string
+	seriesName         string
+	client             types.Client
+	issueDate          types.Date
+	dueDate            types.Date
+	paymentTerms       *types.PaymentTerms
+	deliveryDate       types.Date
+	collectDate        types.Date
+	language           string
+	precision          types.Int
+	currency           string
+	exchangeRate       types.Decimal
+	rows               []types.DocumentRow
+	issuerName         string
+	issuerID           string
+	noticeNumber       string
+	internalNote       string
+	deputyName         string
+	deputyIdentityCard string
+	deputyAuto         string
+	salesAgent         string
+	mentions           string
+	workStation        string
+	collect            types.Collect
+	sendEmail          types.Bool
+	useStock           types.Bool
+	errs               []error
+}
+
+// NewDocument starts describing a document of the given company and series, built with BuildInvoice,
+// BuildProforma or BuildNotice.
+func NewDocument(cif, seriesName string) *DocumentBuilder {
+	return &DocumentBuilder{
+		cif:        cif,
+		seriesName: seriesName,
+	}
+}
+
+// NewInvoice starts describing an invoice of the given company and series, built with BuildInvoice. It is
+// NewDocument under the name of its most common use.
+func NewInvoice(cif, seriesName string) *DocumentBuilder {
+	return NewDocument(cif, seriesName)
+}
+
+func (b *DocumentBuilder) Client(client types.Client) *DocumentBuilder {
+	b.client = client
+
+	return b
+}
+
+func (b *DocumentBuilder) IssueDate(date types.Date) *DocumentBuilder {
+	b.issueDate = date
+
+	return b
+}
+
+func (b *DocumentBuilder) DueDate(date types.Date) *DocumentBuilder {
+	b.dueDate = date
+	b.paymentTerms = nil
+
+	return b
+}
+
+// Due sets the due date the given number of days after the issue date.
+func (b *DocumentBuilder) Due(days int) *DocumentBuilder {
+	return b.PaymentTerms(types.NetDays(days))
+}
+
+// PaymentTerms derives the due date from the issue date and the payment terms of the client.
+func (b *DocumentBuilder) PaymentTerms(terms types.PaymentTerms) *DocumentBuilder {
+	b.paymentTerms = &terms
+	b.dueDate = types.Date{}
+
+	return b
+}
+
+func (b *DocumentBuilder) DeliveryDate(date types.Date) *DocumentBuilder {
+	b.deliveryDate = date
+
+	return b
+}
+
+func (b *DocumentBuilder) CollectDate(date types.Date) *DocumentBuilder {
+	b.collectDate = date
+
+	return b
+}
+
+func (b *DocumentBuilder) Language(code string) *DocumentBuilder {
+	b.language = code
+
+	return b
+}
+
+func (b *DocumentBuilder) Precision(precision int) *DocumentBuilder {
+	b.precision = types.Int(precision)
+
+	return b
+}
+
+func (b *DocumentBuilder) Currency(currency string, exchangeRate types.Decimal) *DocumentBuilder {
+	b.currency = currency
+	b.exchangeRate = exchangeRate
+
+	return b
+}
+
+func (b *DocumentBuilder) Issuer(name, id string) *DocumentBuilder {
+	b.issuerName = name
+	b.issuerID = id
+
+	return b
+}
+
+func (b *DocumentBuilder) Deputy(name, identityCard, auto string) *DocumentBuilder {
+	b.deputyName = name
+	b.deputyIdentityCard = identityCard
+	b.deputyAuto = auto
+
+	return b
+}
+
+func (b *DocumentBuilder) NoticeNumber(number string) *DocumentBuilder {
+	b.noticeNumber = number
+
+	return b
+}
+
+func (b *DocumentBuilder) InternalNote(note string) *DocumentBuilder {
+	b.internalNote = note
+
+	return b
+}
+
+func (b *DocumentBuilder) SalesAgent(name string) *DocumentBuilder {
+	b.salesAgent = name
+
+	return b
+}
+
+func (b *DocumentBuilder) Mentions(mentions string) *DocumentBuilder {
+	b.mentions = mentions
+
+	return b
+}
+
+func (b *DocumentBuilder) WorkStation(workStation string) *DocumentBuilder {
+	b.workStation = workStation
+
+	return b
+}
+
+// Collect marks the invoice as collected on creation. It is ignored for proformas and notices.
+func (b *DocumentBuilder) Collect(collect types.Collect) *DocumentBuilder {
+	b.collect = collect
+
+	return b
+}
+
+func (b *DocumentBuilder) SendEmail(sendEmail bool) *DocumentBuilder {
+	b.sendEmail = types.Bool(sendEmail)
+
+	return b
+}
+
+func (b *DocumentBuilder) UseStock(useStock bool) *DocumentBuilder {
+	b.useStock = types.Bool(useStock)
+
+	return b
+}
+
+func (b *DocumentBuilder) AddLine(item types.LineItem) *DocumentBuilder {
+	b.rows = append(b.rows, copyLineItem(&item))
+
+	return b
+}
+
+// AddDiscount adds a discount for the line added right before it.
+func (b *DocumentBuilder) AddDiscount(name string, value types.Decimal, discountType types.DiscountType) *DocumentBuilder {
+	item := b.lastLine()
+	if item == nil {
+		b.errs = append(b.errs, fmt.Errorf("discount %q has no line to apply to: %w", name, ErrInvalidArgument))
+
+		return b
+	}
+
+	return b.AddDiscountFor(item.Name, name, value, discountType)
+}
+
+// AddDiscountFor adds a discount for the line with the given name.
+func (b *DocumentBuilder) AddDiscountFor(
+	itemName, name string, value types.Decimal, discountType types.DiscountType,
+) *DocumentBuilder {
+	b.rows = append(b.rows, &types.Discount{
+		RefItem:      itemName,
+		Name:         name,
+		Discount:     value,
+		DiscountType: discountType,
+	})
+
+	return b
+}
+
+// AddDiscountAllAbove adds a discount for all the lines added before it.
+func (b *DocumentBuilder) AddDiscountAllAbove(
+	name string, value types.Decimal, discountType types.DiscountType,
+) *DocumentBuilder {
+	b.rows = append(b.rows, &types.Discount{
+		Name:             name,
+		Discount:         value,
+		DiscountType:     discountType,
+		DiscountAllAbove: true,
+	})
+
+	return b
+}
+
+func (b *DocumentBuilder) BuildInvoice() (*CreateInvoiceRequest, error) {
+	issueDate, dueDate, rows, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateInvoiceRequest{
+		CIF:                b.cif,
+		Client:             b.client,
+		IssueDate:          issueDate,
+		DueDate:            dueDate,
+		PaymentTerms:       b.copyPaymentTerms(),
+		DeliveryDate:       b.deliveryDate,
+		CollectDate:        b.collectDate,
+		SeriesName:         b.seriesName,
+		Language:           b.language,
+		Precision:          b.precision,
+		Currency:           b.currency,
+		ExchangeRate:       b.exchangeRate,
+		Products:           rows,
+		IssuerName:         b.issuerName,
+		IssuerID:           b.issuerID,
+		NoticeNumber:       b.noticeNumber,
+		InternalNote:       b.internalNote,
+		DeputyName:         b.deputyName,
+		DeputyIdentityCard: b.deputyIdentityCard,
+		DeputyAuto:         b.deputyAuto,
+		SalesAgent:         b.salesAgent,
+		Mentions:           b.mentions,
+		WorkStation:        b.workStation,
+		Collect:            b.collect,
+		SendEmail:          b.sendEmail,
+		UseStock:           b.useStock,
+	}, nil
+}
+
+func (b *DocumentBuilder) BuildProforma() (*CreateProformaRequest, error) {
+	issueDate, dueDate, rows, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	issuerID, err := b.numericIssuerID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateProformaRequest{
+		CIF:                b.cif,
+		Client:             b.client,
+		IssueDate:          issueDate,
+		DueDate:            dueDate,
+		PaymentTerms:       b.copyPaymentTerms(),
+		SeriesName:         b.seriesName,
+		Language:           b.language,
+		Precision:          b.precision,
+		Currency:           b.currency,
+		ExchangeRate:       b.exchangeRate,
+		Products:           rows,
+		IssuerName:         b.issuerName,
+		IssuerID:           issuerID,
+		NoticeNumber:       b.noticeNumber,
+		InternalNote:       b.internalNote,
+		DeputyName:         b.deputyName,
+		DeputyIdentityCard: b.deputyIdentityCard,
+		DeputyAuto:         b.deputyAuto,
+		SalesAgent:         b.salesAgent,
+		Mentions:           b.mentions,
+		WorkStation:        b.workStation,
+		SendEmail:          bool(b.sendEmail),
+	}, nil
+}
+
+func (b *DocumentBuilder) BuildNotice() (*CreateNoticeRequest, error) {
+	issueDate, dueDate, rows, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	issuerID, err := b.numericIssuerID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateNoticeRequest{
+		CIF:                b.cif,
+		Client:             b.client,
+		IssueDate:          issueDate,
+		DueDate:            dueDate,
+		PaymentTerms:       b.copyPaymentTerms(),
+		SeriesName:         b.seriesName,
+		Language:           b.language,
+		Precision:          b.precision,
+		Currency:           b.currency,
+		ExchangeRate:       b.exchangeRate,
+		Products:           rows,
+		IssuerName:         b.issuerName,
+		IssuerID:           issuerID,
+		InternalNote:       b.internalNote,
+		DeputyName:         b.deputyName,
+		DeputyIdentityCard: b.deputyIdentityCard,
+		DeputyAuto:         b.deputyAuto,
+		SalesAgent:         b.salesAgent,
+		Mentions:           b.mentions,
+		WorkStation:        b.workStation,
+		SendEmail:          b.sendEmail,
+		UseStock:           b.useStock,
+	}, nil
+}
+
+// build validates the document and returns its dates and a deep copy of its rows, so requests built from the
+// builder do not share rows with it or with each other.
+func (b *DocumentBuilder) build() (types.Date, types.Date, []types.DocumentRow, error) {
+	issueDate := b.issueDate
+	if issueDate.IsZero() {
+		issueDate = types.Today()
+	}
+
+	errs := append([]error(nil), b.errs...)
+
+	dueDate := b.dueDate
+	if b.paymentTerms != nil {
+		// Invalid terms are reported by the validation below.
+		if due, err := b.paymentTerms.DueDate(issueDate); err == nil {
+			dueDate = due
+		}
+	}
+
+	errs = append(errs, documentFields{
+		cif:          b.cif,
+		seriesName:   b.seriesName,
+		client:       b.client,
+		issueDate:    issueDate,
+		dueDate:      dueDate,
+		paymentTerms: b.paymentTerms,
+		currency:     b.currency,
+		exchangeRate: b.exchangeRate,
+		products:     b.rows,
+		issuerID:     b.issuerID,
+	}.validate()...)
+
+	if err := errors.Join(errs...); err != nil {
+		return types.Date{}, types.Date{}, nil, err
+	}
+
+	rows := make([]types.DocumentRow, len(b.rows))
+
+	for i, row := range b.rows {
+		switch r := row.(type) {
+		case *types.LineItem:
+			rows[i] = copyLineItem(r)
+		case *types.Discount:
+			discount := *r
+			rows[i] = &discount
+		default:
+			rows[i] = row
+		}
+	}
+
+	return issueDate, dueDate, rows, nil
+}
+
+// copyLineItem copies item along with the values it points to.
+func copyLineItem(item *types.LineItem) *types.LineItem {
+	out := *item
+
+	if item.VATIncluded != nil {
+		out.VATIncluded = types.NewBool(bool(*item.VATIncluded))
+	}
+
+	return &out
+}
+
+func (b *DocumentBuilder) copyPaymentTerms() *types.PaymentTerms {
+	if b.paymentTerms == nil {
+		return nil
+	}
+
+	terms := *b.paymentTerms
+
+	return &terms
+}
+
+func (b *DocumentBuilder) lastLine() *types.LineItem {
+	for i := len(b.rows) - 1; i >= 0; i-- {
+		if item, ok := b.rows[i].(*types.LineItem); ok {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func (b *DocumentBuilder) numericIssuerID() (int64, error) {
+	if b.issuerID == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(b.issuerID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("issuerId %q is not numeric: %w", b.issuerID, ErrInvalidArgument)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,152 @@
+package oblio_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestDocumentBuilder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client = types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"}
+		item   = types.LineItem{Name: "Abonament", Price: "100", Quantity: "1", VATName: "Normala"}
+	)
+
+	t.Run("invoice", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := oblio.NewInvoice("RO12345674", "FCT").
+			Client(client).
+			IssueDate(types.NewDate(2024, 1, 15)).
+			Due(30).
+			AddLine(item).
+			AddDiscount("Reducere", "10", types.PercentageDiscountType).
+			AddDiscountAllAbove("Fidelitate", "5", types.FlatDiscountType).
+			BuildInvoice()
+		require.NoError(t, err)
+
+		terms := types.NetDays(30)
+
+		require.Equal(t, &oblio.CreateInvoiceRequest{
+			CIF:          "RO12345674",
+			SeriesName:   "FCT",
+			Client:       client,
+			IssueDate:    types.NewDate(2024, 1, 15),
+			DueDate:      types.NewDate(2024, 2, 14),
+			PaymentTerms: &terms,
+			Products: []types.DocumentRow{
+				&item,
+				&types.Discount{
+					RefItem:      "Abonament",
+					Name:         "Reducere",
+					Discount:     "10",
+					DiscountType: types.PercentageDiscountType,
+				},
+				&types.Discount{
+					Name:             "Fidelitate",
+					Discount:         "5",
+					DiscountType:     types.FlatDiscountType,
+					DiscountAllAbove: true,
+				},
+			},
+		}, got)
+	})
+
+	t.Run("same description for every document type", func(t *testing.T) {
+		t.Parallel()
+
+		builder := oblio.NewDocument("RO12345674", "PRF").
+			Client(client).
+			IssueDate(types.NewDate(2024, 1, 15)).
+			Issuer("Ion Popescu", "1820913326910").
+			AddLine(item)
+
+		invoice, err := builder.BuildInvoice()
+		require.NoError(t, err)
+		require.Equal(t, "1820913326910", invoice.IssuerID)
+
+		proforma, err := builder.BuildProforma()
+		require.NoError(t, err)
+		require.Equal(t, int64(1820913326910), proforma.IssuerID)
+		require.Equal(t, invoice.Products, proforma.Products)
+
+		notice, err := builder.BuildNotice()
+		require.NoError(t, err)
+		require.Equal(t, invoice.IssueDate, notice.IssueDate)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := oblio.NewDocument("", "").
+			IssueDate(types.NewDate(2024, 1, 15)).
+			DueDate(types.NewDate(2024, 1, 1)).
+			AddDiscount("Reducere", "10", types.PercentageDiscountType).
+			AddDiscountFor("Missing", "Reducere", "10", types.PercentageDiscountType).
+			AddLine(types.LineItem{Price: "1"}).
+			BuildInvoice()
+		require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+
+		for _, want := range []string{"cif", "seriesName", "client", "dueDate", "no line to apply", `"Missing"`, "name is empty"} {
+			require.ErrorContains(t, err, want)
+		}
+	})
+
+	t.Run("payment terms", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := oblio.NewInvoice("RO12345674", "FCT").
+			Client(client).
+			IssueDate(types.NewDate(2024, 4, 29)).
+			PaymentTerms(types.BusinessDays(5)).
+			AddLine(item).
+			BuildInvoice()
+		require.NoError(t, err)
+		require.Equal(t, types.NewDate(2024, 5, 9), got.DueDate)
+
+		_, err = oblio.NewInvoice("RO12345674", "FCT").
+			Client(client).
+			PaymentTerms(types.DayOfNextMonth(32)).
+			AddLine(item).
+			BuildInvoice()
+		require.ErrorContains(t, err, "paymentTerms.day")
+	})
+
+	t.Run("requests do not share rows", func(t *testing.T) {
+		t.Parallel()
+
+		item := item
+		item.VATIncluded = types.NewBool(false)
+
+		builder := oblio.NewInvoice("RO12345674", "FCT").Client(client).AddLine(item)
+
+		first, err := builder.BuildInvoice()
+		require.NoError(t, err)
+
+		first.Products[0].(*types.LineItem).Price = "1"
+		*first.Products[0].(*types.LineItem).VATIncluded = true
+
+		second, err := builder.AddLine(item).BuildInvoice()
+		require.NoError(t, err)
+		require.Len(t, first.Products, 1)
+		require.Len(t, second.Products, 2)
+		require.Equal(t, types.Decimal("100"), second.Products[0].(*types.LineItem).Price)
+		require.Equal(t, types.NewBool(false), second.Products[0].(*types.LineItem).VATIncluded)
+		require.Equal(t, types.NewBool(false), item.VATIncluded)
+	})
+
+	t.Run("non numeric issuer id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := oblio.NewDocument("RO12345674", "PRF").
+			Client(client).
+			Issuer("Ion Popescu", "abc").
+			AddLine(item).
+			BuildProforma()
+		require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,278 @@
+package oblio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// revalidateTimeout bounds the background refresh of a stale entry, which outlives the request that started it.
+const revalidateTimeout = time.Minute
+
+type CacheStorage interface {
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+type NomenclatureEndpoint string
+
+const (
+	CompaniesEndpoint  NomenclatureEndpoint = "companies"
+	VATRatesEndpoint   NomenclatureEndpoint = "vat_rates"
+	ClientsEndpoint    NomenclatureEndpoint = "clients"
+	ProductsEndpoint   NomenclatureEndpoint = "products"
+	SeriesEndpoint     NomenclatureEndpoint = "series"
+	LanguagesEndpoint  NomenclatureEndpoint = "languages"
+	ManagementEndpoint NomenclatureEndpoint = "management"
+)
+
+// CacheTTL controls how long a cached nomenclature response is served. Within TTL the entry is fresh; for
+// another Stale duration it is still served while being refreshed in the background.
+type CacheTTL struct {
+	TTL   time.Duration
+	Stale time.Duration
+}
+
+func DefaultCacheTTLs() map[NomenclatureEndpoint]CacheTTL {
+	return map[NomenclatureEndpoint]CacheTTL{
+		CompaniesEndpoint:  {TTL: time.Hour, Stale: 24 * time.Hour},
+		VATRatesEndpoint:   {TTL: time.Hour, Stale: 24 * time.Hour},
+		SeriesEndpoint:     {TTL: 10 * time.Minute, Stale: time.Hour},
+		LanguagesEndpoint:  {TTL: 24 * time.Hour, Stale: 7 * 24 * time.Hour},
+		ManagementEndpoint: {TTL: time.Hour, Stale: 24 * time.Hour},
+	}
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type nomenclatureCache struct {
+	storage    CacheStorage
+	ttls       map[NomenclatureEndpoint]CacheTTL
+	onError    func(err error)
+	mu         sync.Mutex
+	refreshing map[string]struct{}
+}
+
+func newNomenclatureCache(
+	storage CacheStorage, ttls map[NomenclatureEndpoint]CacheTTL, onError func(err error),
+) *nomenclatureCache {
+	if storage == nil {
+		return nil
+	}
+
+	return &nomenclatureCache{
+		storage:    storage,
+		ttls:       ttls,
+		onError:    onError,
+		refreshing: make(map[string]struct{}),
+	}
+}
+
+// report hands an error that does not fail the call to the cache error handler, if any.
+func (c *nomenclatureCache) report(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+func (c *nomenclatureCache) ttl(endpoint NomenclatureEndpoint) (CacheTTL, bool) {
+	if c == nil {
+		return CacheTTL{}, false
+	}
+
+	ttl, ok := c.ttls[endpoint]
+
+	return ttl, ok && ttl.TTL > 0
+}
+
+func (c *nomenclatureCache) load(ctx context.Context, key string) (*cacheEntry, bool) {
+	data, err := c.storage.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+
+	entry := &cacheEntry{}
+
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (c *nomenclatureCache) store(ctx context.Context, key string, ttl CacheTTL, resp any) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	entry, err := json.Marshal(cacheEntry{
+		FetchedAt: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := c.storage.Set(ctx, key, entry, ttl.TTL+ttl.Stale); err != nil {
+		return fmt.Errorf("set: %w", err)
+	}
+
+	return nil
+}
+
+func (c *nomenclatureCache) startRefresh(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.refreshing[key]; ok {
+		return false
+	}
+
+	c.refreshing[key] = struct{}{}
+
+	return true
+}
+
+func (c *nomenclatureCache) endRefresh(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.refreshing, key)
+}
+
+func (c *Client) cacheKeyPrefix(cif string) string {
+	return c.clientID + "/" + cif + "/"
+}
+
+func (c *Client) cacheKey(endpoint NomenclatureEndpoint, req any) (string, error) {
+	values, err := query.Values(req)
+	if err != nil {
+		return "", fmt.Errorf("values: %w", err)
+	}
+
+	return c.cacheKeyPrefix(values.Get("cif")) + string(endpoint) + "?" + values.Encode(), nil
+}
+
+func (c *Client) callCachedNomenclatureAPI(
+	ctx context.Context, endpoint NomenclatureEndpoint, ttl CacheTTL, req, resp any,
+) error {
+	key, err := c.cacheKey(endpoint, req)
+	if err != nil {
+		return fmt.Errorf("cacheKey: %w", err)
+	}
+
+	entry, ok := c.cache.load(ctx, key)
+	if ok && json.Unmarshal(entry.Data, resp) == nil {
+		if time.Since(entry.FetchedAt) >= ttl.TTL {
+			c.revalidate(ctx, endpoint, key, ttl, req, resp)
+		}
+
+		return nil
+	}
+
+	return c.fetchNomenclature(ctx, endpoint, key, ttl, req, resp)
+}
+
+// fetchNomenclature calls the API and caches the response. A failure to cache it does not fail the call and is
+// only reported to the cache error handler.
+func (c *Client) fetchNomenclature(
+	ctx context.Context, endpoint NomenclatureEndpoint, key string, ttl CacheTTL, req, resp any,
+) error {
+	if err := c.callAPI(ctx, http.MethodGet, "/nomenclature", string(endpoint), req, resp); err != nil {
+		return fmt.Errorf("callAPI: %w", err)
+	}
+
+	if err := c.cache.store(ctx, key, ttl, resp); err != nil {
+		c.cache.report(fmt.Errorf("store %s: %w", endpoint, err))
+	}
+
+	return nil
+}
+
+func (c *Client) revalidate(
+	ctx context.Context, endpoint NomenclatureEndpoint, key string, ttl CacheTTL, req, resp any,
+) {
+	if !c.cache.startRefresh(key) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), revalidateTimeout)
+	fresh := reflect.New(reflect.TypeOf(resp).Elem()).Interface()
+
+	go func() {
+		defer cancel()
+		defer c.cache.endRefresh(key)
+
+		if err := c.fetchNomenclature(ctx, endpoint, key, ttl, req, fresh); err != nil {
+			c.cache.report(fmt.Errorf("revalidate %s: %w", endpoint, err))
+		}
+	}()
+}
+
+// InvalidateNomenclature drops every cached nomenclature response for the given CIF. An empty CIF drops all
+// the entries cached by this client, including the companies list.
+func (c *Client) InvalidateNomenclature(ctx context.Context, cif string) error {
+	if c.cache == nil {
+		return nil
+	}
+
+	prefix := c.clientID + "/"
+	if cif != "" {
+		prefix = c.cacheKeyPrefix(cif)
+	}
+
+	if err := c.cache.storage.DeletePrefix(ctx, prefix); err != nil {
+		return fmt.Errorf("deletePrefix: %w", err)
+	}
+
+	return nil
+}
+
+// WarmupNomenclature fetches and caches the nomenclature used when building documents for the given CIF, so
+// later calls are served from the cache. Responses that cannot be cached are reported like on any other call.
+func (c *Client) WarmupNomenclature(ctx context.Context, cif string) error {
+	if c.cache == nil {
+		return nil
+	}
+
+	calls := []struct {
+		endpoint NomenclatureEndpoint
+		req      any
+		resp     any
+	}{
+		{endpoint: CompaniesEndpoint, req: &GetCompaniesRequest{}, resp: &GetCompaniesResponse{}},
+		{endpoint: VATRatesEndpoint, req: &GetVATRatesRequest{CIF: cif}, resp: &GetVATRatesResponse{}},
+		{endpoint: SeriesEndpoint, req: &GetSeriesRequest{CIF: cif}, resp: &GetSeriesResponse{}},
+		{endpoint: LanguagesEndpoint, req: &GetLanguagesRequest{CIF: cif}, resp: &GetLanguagesResponse{}},
+		{endpoint: ManagementEndpoint, req: &GetManagementRequest{CIF: cif}, resp: &GetManagementResponse{}},
+	}
+
+	for _, call := range calls {
+		ttl, ok := c.cache.ttl(call.endpoint)
+		if !ok {
+			continue
+		}
+
+		key, err := c.cacheKey(call.endpoint, call.req)
+		if err != nil {
+			return fmt.Errorf("cacheKey: %w", err)
+		}
+
+		if err := c.fetchNomenclature(ctx, call.endpoint, key, ttl, call.req, call.resp); err != nil {
+			return fmt.Errorf("%s: %w", call.endpoint, err)
+		}
+	}
+
+	return nil
+}
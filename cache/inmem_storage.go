@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type InMemStorage struct {
+	entries map[string]entry
+	mu      sync.Mutex
+}
+
+func NewInMemStorage() *InMemStorage {
+	return &InMemStorage{
+		entries: make(map[string]entry),
+	}
+}
+
+func (s *InMemStorage) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = entry{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	return nil
+}
+
+func (s *InMemStorage) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if e.expiresAt.Before(time.Now()) {
+		delete(s.entries, key)
+
+		return nil, ErrNotFound
+	}
+
+	return e.value, nil
+}
+
+func (s *InMemStorage) DeletePrefix(_ context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.entries, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/cache"
+)
+
+func TestInMemStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			storage = cache.NewInMemStorage()
+			ctx     = context.Background()
+		)
+
+		err := storage.Set(ctx, "key", []byte("value"), time.Second/4)
+		require.NoError(t, err)
+
+		time.Sleep(time.Second / 2)
+
+		got, err := storage.Get(ctx, "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+		require.Empty(t, got)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := cache.NewInMemStorage().Get(context.Background(), "key")
+		require.ErrorIs(t, err, cache.ErrNotFound)
+		require.Empty(t, got)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			storage = cache.NewInMemStorage()
+			ctx     = context.Background()
+			want    = []byte("value")
+		)
+
+		err := storage.Set(ctx, "key", want, time.Hour)
+		require.NoError(t, err)
+
+		got, err := storage.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+}
+
+func TestInMemStorage_DeletePrefix(t *testing.T) {
+	t.Parallel()
+
+	var (
+		storage = cache.NewInMemStorage()
+		ctx     = context.Background()
+	)
+
+	require.NoError(t, storage.Set(ctx, "a/1", []byte("1"), time.Hour))
+	require.NoError(t, storage.Set(ctx, "a/2", []byte("2"), time.Hour))
+	require.NoError(t, storage.Set(ctx, "b/1", []byte("3"), time.Hour))
+
+	err := storage.DeletePrefix(ctx, "a/")
+	require.NoError(t, err)
+
+	_, err = storage.Get(ctx, "a/1")
+	require.ErrorIs(t, err, cache.ErrNotFound)
+
+	_, err = storage.Get(ctx, "a/2")
+	require.ErrorIs(t, err, cache.ErrNotFound)
+
+	got, err := storage.Get(ctx, "b/1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("3"), got)
+}
@@ -0,0 +1,207 @@
+package oblio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/cache"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func StartCountingServer(t *testing.T, hits *atomic.Int32) string {
+	t.Helper()
+
+	authHandler := NewAuthorizationHandler(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI == "/authorize/token" {
+			authHandler(w, r)
+
+			return
+		}
+
+		hits.Add(1)
+
+		_, err := w.Write([]byte(`{"status":200,"statusMessage":"Success","data":[{"name":"Normala","percent":19}]}`))
+		require.NoError(t, err)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+// failingStorage is a cache storage whose writes always fail.
+type failingStorage struct {
+	oblio.CacheStorage
+}
+
+func (failingStorage) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("storage is down")
+}
+
+func TestClient_NomenclatureCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		req = &oblio.GetVATRatesRequest{CIF: "123"}
+	)
+
+	t.Run("serves fresh entries from cache", func(t *testing.T) {
+		t.Parallel()
+
+		var hits atomic.Int32
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(cache.NewInMemStorage()),
+		)
+
+		for range 3 {
+			got, err := client.GetVATRates(ctx, req)
+			require.NoError(t, err)
+			require.Len(t, got.Data, 1)
+			require.Equal(t, types.Decimal("19"), got.Data[0].Percent)
+		}
+
+		require.EqualValues(t, 1, hits.Load())
+
+		_, err := client.GetVATRates(ctx, &oblio.GetVATRatesRequest{CIF: "456"})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, hits.Load())
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		t.Parallel()
+
+		var hits atomic.Int32
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(cache.NewInMemStorage()),
+		)
+
+		_, err := client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+
+		err = client.InvalidateNomenclature(ctx, req.CIF)
+		require.NoError(t, err)
+
+		_, err = client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, hits.Load())
+	})
+
+	t.Run("stale while revalidate", func(t *testing.T) {
+		t.Parallel()
+
+		var hits atomic.Int32
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(cache.NewInMemStorage()),
+			oblio.WithCacheTTL(oblio.VATRatesEndpoint, oblio.CacheTTL{TTL: time.Millisecond, Stale: time.Hour}),
+		)
+
+		_, err := client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond * 5)
+
+		got, err := client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, got.Data, 1)
+
+		require.Eventually(t, func() bool {
+			return hits.Load() == 2
+		}, time.Second, time.Millisecond*10)
+	})
+
+	t.Run("warmup", func(t *testing.T) {
+		t.Parallel()
+
+		var hits atomic.Int32
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(cache.NewInMemStorage()),
+		)
+
+		err := client.WarmupNomenclature(ctx, req.CIF)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, hits.Load())
+
+		_, err = client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, hits.Load())
+	})
+
+	t.Run("uncached endpoint", func(t *testing.T) {
+		t.Parallel()
+
+		var hits atomic.Int32
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(cache.NewInMemStorage()),
+		)
+
+		for range 2 {
+			_, err := client.GetClients(ctx, &oblio.GetClientsRequest{CIF: "123"})
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 2, hits.Load())
+	})
+
+	t.Run("failed cache write", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			hits atomic.Int32
+			mu   sync.Mutex
+			errs []error
+		)
+
+		client := oblio.NewClient(
+			clientID, clientSecret,
+			oblio.WithBaseURL(StartCountingServer(t, &hits)),
+			oblio.WithCache(failingStorage{CacheStorage: cache.NewInMemStorage()}),
+			oblio.WithCacheErrorHandler(func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				errs = append(errs, err)
+			}),
+		)
+
+		got, err := client.GetVATRates(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, got.Data, 1)
+
+		require.NoError(t, client.WarmupNomenclature(ctx, req.CIF))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		require.NotEmpty(t, errs)
+
+		for _, err := range errs {
+			require.ErrorContains(t, err, "storage is down")
+		}
+	})
+}
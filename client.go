@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/vcraescu/go-oblio-api/types"
 	"github.com/vcraescu/go-reqbuilder"
 )
 
@@ -26,25 +27,33 @@ type TokenStorage interface {
 }
 
 type Client struct {
-	clientID       string
-	clientSecret   string
-	baseURL        string
-	httpClient     *http.Client
-	requestBuilder reqbuilder.Builder
-	tokenStorage   TokenStorage
-	tokenMu        sync.Mutex
+	clientID         string
+	clientSecret     string
+	baseURL          string
+	httpClient       *http.Client
+	requestBuilder   reqbuilder.Builder
+	tokenStorage     TokenStorage
+	tokenMu          sync.Mutex
+	cache            *nomenclatureCache
+	preflightEnabled bool
+	exchangeRates    ExchangeRateProvider
+	strictEnums      bool
 }
 
 func NewClient(clientID, clientSecret string, opts ...Option) *Client {
 	options := newOptions(opts)
 
 	return &Client{
-		clientID:       clientID,
-		clientSecret:   clientSecret,
-		baseURL:        options.baseURL,
-		httpClient:     options.client,
-		requestBuilder: reqbuilder.NewBuilder(options.baseURL),
-		tokenStorage:   options.tokenStorage,
+		clientID:         clientID,
+		clientSecret:     clientSecret,
+		baseURL:          options.baseURL,
+		httpClient:       options.client,
+		requestBuilder:   reqbuilder.NewBuilder(options.baseURL),
+		tokenStorage:     options.tokenStorage,
+		cache:            newNomenclatureCache(options.cacheStorage, options.cacheTTLs, options.cacheErrors),
+		preflightEnabled: options.preflight,
+		exchangeRates:    options.rates,
+		strictEnums:      options.strictEnums,
 	}
 }
 
@@ -64,7 +73,15 @@ func (c *Client) do(ctx context.Context, builder reqbuilder.Builder, out any) er
 		return UnmarshalErrorResponse(resp)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+	dec := json.NewDecoder(resp.Body)
+
+	if stream, ok := out.(streamDecoder); ok {
+		err = stream.decodeStream(dec)
+	} else {
+		err = dec.Decode(out)
+	}
+
+	if err != nil {
 		return fmt.Errorf("decode: %w", err)
 	}
 
@@ -129,6 +146,12 @@ func (c *Client) callAPI(ctx context.Context, method, baseURL, endpointSuffix st
 		}
 	}
 
+	if c.strictEnums {
+		if err := types.ValidateEnums(req); err != nil {
+			return err
+		}
+	}
+
 	var accessToken string
 
 	if v, ok := req.(interface{ GetAccessToken() string }); ok {
@@ -0,0 +1,337 @@
+package oblio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+type ClientMatch string
+
+const (
+	CIFClientMatch   ClientMatch = "cif"
+	EmailClientMatch ClientMatch = "email"
+	PhoneClientMatch ClientMatch = "phone"
+	CodeClientMatch  ClientMatch = "code"
+)
+
+type ResolveClientRequest struct {
+	// CIF is the fiscal code of the company whose clients are searched.
+	CIF string
+	// Client is the customer as known by the caller, identified by its CIF, email, phone or code.
+	Client types.Client
+}
+
+func (r *ResolveClientRequest) Validate() error {
+	var errs []error
+
+	if r.CIF == "" {
+		errs = append(errs, fmt.Errorf("cif is empty: %w", ErrInvalidArgument))
+	}
+
+	if r.Client.CIF == "" && r.Client.Email == "" && r.Client.Phone == "" && r.Client.Code == "" {
+		errs = append(errs, fmt.Errorf("client has no cif, email, phone or code: %w", ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ClientConflict is a field set differently on the requested client and on the client found in Oblio.
+type ClientConflict struct {
+	Field    string
+	Value    string
+	Existing string
+}
+
+type ResolveClientResponse struct {
+	// Client is ready to embed in a create request, with Save and Autocomplete set.
+	Client types.Client
+	// Found is set when the client exists in Oblio; Existing is then the canonical match.
+	Found     bool
+	MatchedBy ClientMatch
+	Existing  types.Client
+	// Duplicates are the other clients in Oblio matching the requested one.
+	Duplicates []types.Client
+	Conflicts  []ClientConflict
+}
+
+// ResolveClient finds the requested client among the clients of the company, by CIF first and then by email,
+// phone or code, the latter scanning every page of GetClients. The canonical match is the one sharing the most
+// identifiers with the requested client. A client found is returned as stored in Oblio, without saving it again;
+// a new one is saved and, for Romanian companies, autocompleted by Oblio from its CIF.
+func (c *Client) ResolveClient(ctx context.Context, req *ResolveClientRequest) (*ResolveClientResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	matches, err := c.findClients(ctx, req.CIF, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matches) == 0 {
+		client := req.Client
+		client.Save = true
+		client.Autocomplete = types.Bool(types.ValidateCIF(client.CIF) == nil)
+
+		return &ResolveClientResponse{Client: client}, nil
+	}
+
+	best := 0
+
+	for i := range matches {
+		if clientMatches(req.Client, matches[i]) > clientMatches(req.Client, matches[best]) {
+			best = i
+		}
+	}
+
+	existing := matches[best]
+	client := existing
+	client.Save = false
+	client.Autocomplete = false
+
+	return &ResolveClientResponse{
+		Client:     client,
+		Found:      true,
+		MatchedBy:  matchedBy(req.Client, existing),
+		Existing:   existing,
+		Duplicates: slices.Delete(matches, best, best+1),
+		Conflicts:  clientConflicts(req.Client, existing),
+	}, nil
+}
+
+// UpsertClient resolves the requested client like ResolveClient. A client found is updated with the non-empty
+// fields of the requested one and saved when any of them differs.
+func (c *Client) UpsertClient(ctx context.Context, req *ResolveClientRequest) (*ResolveClientResponse, error) {
+	resp, err := c.ResolveClient(ctx, req)
+	if err != nil || !resp.Found {
+		return resp, err
+	}
+
+	var (
+		client = resp.Client
+		fields = clientFields(&client)
+		values = clientFields(&req.Client)
+	)
+
+	for _, name := range clientFieldNames {
+		if value := *values[name]; value != "" && !sameClientField(name, value, *fields[name]) {
+			*fields[name] = value
+			client.Save = true
+		}
+	}
+
+	if req.Client.VATPayer && !client.VATPayer {
+		client.VATPayer = true
+		client.Save = true
+	}
+
+	resp.Client = client
+
+	return resp, nil
+}
+
+// findClients returns the clients of the company with the CIF of client or, when there are none, with its
+// email, phone or code. A client stored with another CIF is another company, so it never matches.
+func (c *Client) findClients(ctx context.Context, cif string, client types.Client) ([]types.Client, error) {
+	var (
+		matches []types.Client
+		want    = normalizeClientCIF(client.CIF)
+	)
+
+	if client.CIF != "" {
+		for _, clientCIF := range []string{client.CIF, clientCIFVariant(client.CIF)} {
+			if clientCIF == "" {
+				continue
+			}
+
+			err := c.listClients(ctx, &GetClientsRequest{CIF: cif, ClientCIF: clientCIF}, func(found types.Client) {
+				if normalizeClientCIF(found.CIF) == want {
+					matches = append(matches, found)
+				}
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(matches) > 0 {
+				return matches, nil
+			}
+		}
+	}
+
+	if client.Email == "" && client.Phone == "" && client.Code == "" {
+		return nil, nil
+	}
+
+	err := c.listClients(ctx, &GetClientsRequest{CIF: cif}, func(found types.Client) {
+		if found.CIF != "" && want != "" && normalizeClientCIF(found.CIF) != want {
+			return
+		}
+
+		if matchedBy(client, found) != "" {
+			matches = append(matches, found)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// listClients calls fn with every client matching req.
+func (c *Client) listClients(ctx context.Context, req *GetClientsRequest, fn func(client types.Client)) error {
+	it := c.Clients(ctx, req)
+	defer it.Close()
+
+	for it.Next() {
+		fn(it.Value())
+	}
+
+	return it.Err()
+}
+
+// matchedBy returns the first identifier of want that found shares.
+func matchedBy(want, found types.Client) ClientMatch {
+	for _, id := range clientIdentifiers(want, found) {
+		if id.a != "" && id.a == id.b {
+			return id.match
+		}
+	}
+
+	return ""
+}
+
+// clientMatches counts the identifiers of want that found shares.
+func clientMatches(want, found types.Client) int {
+	var n int
+
+	for _, id := range clientIdentifiers(want, found) {
+		if id.a != "" && id.a == id.b {
+			n++
+		}
+	}
+
+	return n
+}
+
+type clientIdentifier struct {
+	match ClientMatch
+	a, b  string
+}
+
+func clientIdentifiers(a, b types.Client) []clientIdentifier {
+	return []clientIdentifier{
+		{match: CIFClientMatch, a: normalizeClientCIF(a.CIF), b: normalizeClientCIF(b.CIF)},
+		{match: EmailClientMatch, a: normalizeEmail(a.Email), b: normalizeEmail(b.Email)},
+		{match: PhoneClientMatch, a: normalizePhone(a.Phone), b: normalizePhone(b.Phone)},
+		{match: CodeClientMatch, a: strings.TrimSpace(a.Code), b: strings.TrimSpace(b.Code)},
+	}
+}
+
+// clientConflicts lists the fields set on both clients to values that differ beyond case and spacing.
+func clientConflicts(want, existing types.Client) []ClientConflict {
+	var (
+		conflicts []ClientConflict
+		values    = clientFields(&want)
+		current   = clientFields(&existing)
+	)
+
+	for _, name := range clientFieldNames {
+		value, existing := *values[name], *current[name]
+
+		if value == "" || existing == "" || sameClientField(name, value, existing) {
+			continue
+		}
+
+		conflicts = append(conflicts, ClientConflict{Field: name, Value: value, Existing: existing})
+	}
+
+	return conflicts
+}
+
+var clientFieldNames = []string{
+	"cif", "name", "rc", "code", "address", "state", "city", "country", "iban", "bank", "email", "phone", "contact",
+}
+
+func clientFields(c *types.Client) map[string]*string {
+	return map[string]*string{
+		"cif":     &c.CIF,
+		"name":    &c.Name,
+		"rc":      &c.RC,
+		"code":    &c.Code,
+		"address": &c.Address,
+		"state":   &c.State,
+		"city":    &c.City,
+		"country": &c.Country,
+		"iban":    &c.IBAN,
+		"bank":    &c.Bank,
+		"email":   &c.Email,
+		"phone":   &c.Phone,
+		"contact": &c.Contact,
+	}
+}
+
+func sameClientField(name, a, b string) bool {
+	switch name {
+	case "cif":
+		return normalizeClientCIF(a) == normalizeClientCIF(b)
+	case "phone":
+		return normalizePhone(a) == normalizePhone(b)
+	case "email":
+		return normalizeEmail(a) == normalizeEmail(b)
+	case "iban":
+		return types.NormalizeIBAN(a) == types.NormalizeIBAN(b)
+	default:
+		return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func normalizeClientCIF(cif string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.Join(strings.Fields(cif), "")), "RO")
+}
+
+// clientCIFVariant returns a Romanian cif with the RO prefix added or removed, as Oblio may store either, and
+// an empty string for other codes.
+func clientCIFVariant(cif string) string {
+	cif = strings.ToUpper(strings.Join(strings.Fields(cif), ""))
+	trimmed, hasPrefix := strings.CutPrefix(cif, "RO")
+
+	if types.ValidateCIF(trimmed) != nil {
+		return ""
+	}
+
+	if hasPrefix {
+		return trimmed
+	}
+
+	return "RO" + cif
+}
+
+// normalizePhone keeps the digits of a phone number, writing Romanian numbers in their national form.
+func normalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+
+		return r
+	}, phone)
+
+	digits = strings.TrimPrefix(digits, "00")
+
+	if strings.HasPrefix(digits, "40") && len(digits) == 11 {
+		return "0" + digits[2:]
+	}
+
+	return digits
+}
@@ -0,0 +1,157 @@
+package oblio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestClient_ResolveClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newClient := func(t *testing.T) *oblio.Client {
+		t.Helper()
+
+		srv := obliotest.NewServer(obliotest.WithPageSize(2))
+		t.Cleanup(srv.Close)
+
+		for _, client := range []types.Client{
+			{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL", City: "București", Email: "office@oblio.eu"},
+			{CIF: "12345674", Name: "FIRMA SRL"},
+			{Name: "Ion Popescu", Email: "ion@example.com", Phone: "0723 111 222"},
+			{Name: "Ion Popescu", Email: "ion@example.com", Phone: "0723111222", Code: "C-42"},
+			{Name: "Maria Ionescu", Code: "C-7"},
+		} {
+			srv.AddClient(obliotest.DefaultCIF, client)
+		}
+
+		return oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+	}
+
+	t.Run("by cif without prefix", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{
+			CIF:    obliotest.DefaultCIF,
+			Client: types.Client{CIF: "37311090", Name: "Oblio Software SRL", City: "Cluj-Napoca"},
+		})
+		require.NoError(t, err)
+		require.True(t, got.Found)
+		require.Equal(t, oblio.CIFClientMatch, got.MatchedBy)
+		require.Equal(t, "RO37311090", got.Client.CIF)
+		require.False(t, bool(got.Client.Save))
+		require.Empty(t, got.Duplicates)
+		require.Equal(t, []oblio.ClientConflict{
+			{Field: "city", Value: "Cluj-Napoca", Existing: "București"},
+		}, got.Conflicts)
+	})
+
+	t.Run("by cif with prefix", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{
+			CIF:    obliotest.DefaultCIF,
+			Client: types.Client{CIF: "RO12345674"},
+		})
+		require.NoError(t, err)
+		require.True(t, got.Found)
+		require.Equal(t, "FIRMA SRL", got.Client.Name)
+		require.Empty(t, got.Conflicts)
+	})
+
+	t.Run("canonical match across pages", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{
+			CIF:    obliotest.DefaultCIF,
+			Client: types.Client{Email: "ION@example.com", Phone: "+40 723 111 222", Code: "C-42"},
+		})
+		require.NoError(t, err)
+		require.True(t, got.Found)
+		require.Equal(t, oblio.EmailClientMatch, got.MatchedBy)
+		require.Equal(t, "C-42", got.Existing.Code)
+		require.Len(t, got.Duplicates, 1)
+		require.Empty(t, got.Duplicates[0].Code)
+	})
+
+	t.Run("email of a client with another cif", func(t *testing.T) {
+		t.Parallel()
+
+		want := types.Client{CIF: "RO18547290", Name: "ALTA FIRMA SRL", Email: "office@oblio.eu"}
+
+		got, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{
+			CIF:    obliotest.DefaultCIF,
+			Client: want,
+		})
+		require.NoError(t, err)
+		require.False(t, got.Found)
+		require.Equal(t, "RO18547290", got.Client.CIF)
+		require.True(t, bool(got.Client.Save))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{
+			CIF:    obliotest.DefaultCIF,
+			Client: types.Client{CIF: "RO19", Name: "ALTA FIRMA SRL", Email: "x@example.com"},
+		})
+		require.NoError(t, err)
+		require.False(t, got.Found)
+		require.Equal(t, types.Client{
+			CIF:          "RO19",
+			Name:         "ALTA FIRMA SRL",
+			Email:        "x@example.com",
+			Save:         true,
+			Autocomplete: true,
+		}, got.Client)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newClient(t).ResolveClient(ctx, &oblio.ResolveClientRequest{Client: types.Client{Name: "Ion"}})
+		require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+		require.ErrorContains(t, err, "cif is empty")
+		require.ErrorContains(t, err, "no cif, email, phone or code")
+	})
+}
+
+func TestClient_UpsertClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := obliotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddClient(obliotest.DefaultCIF, types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL", City: "București"})
+
+	client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+
+	got, err := client.UpsertClient(ctx, &oblio.ResolveClientRequest{
+		CIF:    obliotest.DefaultCIF,
+		Client: types.Client{CIF: "37311090", Name: "oblio software srl", Email: "office@oblio.eu"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.Client{
+		CIF:   "RO37311090",
+		Name:  "OBLIO SOFTWARE SRL",
+		City:  "București",
+		Email: "office@oblio.eu",
+		Save:  true,
+	}, got.Client)
+
+	got, err = client.UpsertClient(ctx, &oblio.ResolveClientRequest{
+		CIF:    obliotest.DefaultCIF,
+		Client: types.Client{CIF: "RO37311090", City: "bucurești"},
+	})
+	require.NoError(t, err)
+	require.False(t, bool(got.Client.Save))
+}
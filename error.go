@@ -6,10 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/vcraescu/go-oblio-api/types"
 )
 
 var (
-	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrInvalidArgument is the same error as types.ErrInvalidArgument, so validation errors of requests and of
+	// the types they embed match either.
+	ErrInvalidArgument = types.ErrInvalidArgument
+	// ErrNomenclatureMismatch is returned by Preflight when a request refers to nomenclature the company lacks.
+	ErrNomenclatureMismatch = errors.New("nomenclature mismatch")
 )
 
 type ErrorResponse struct {
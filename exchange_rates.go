@@ -0,0 +1,40 @@
+package oblio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+// ExchangeRateProvider returns the RON value of one unit of a currency on a date, e.g. bnr.Provider.
+type ExchangeRateProvider interface {
+	ExchangeRate(ctx context.Context, currency string, date types.Date) (types.Decimal, error)
+}
+
+// withExchangeRate returns a copy of req with the rate of a foreign-currency document left without one set to
+// the rate of its issue date, today when the issue date is not set. Other requests are returned as they are; req
+// itself is never modified.
+func withExchangeRate[R any, P interface {
+	*R
+	CreateDocumentRequest
+}](ctx context.Context, c *Client, req P) (P, error) {
+	doc := req.documentFields()
+
+	if c.exchangeRates == nil || !doc.exchangeRate.IsEmpty() || doc.currency == "" ||
+		strings.EqualFold(doc.currency, "RON") {
+		return req, nil
+	}
+
+	rate, err := c.exchangeRates.ExchangeRate(ctx, doc.currency, doc.issueDateOrToday())
+	if err != nil {
+		return nil, fmt.Errorf("exchangeRate: %w", err)
+	}
+
+	filled := P(new(R))
+	*filled = *req
+	filled.setExchangeRate(rate)
+
+	return filled, nil
+}
@@ -0,0 +1,138 @@
+package oblio_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+type exchangeRatesFunc func(ctx context.Context, currency string, date types.Date) (types.Decimal, error)
+
+func (fn exchangeRatesFunc) ExchangeRate(ctx context.Context, currency string, date types.Date) (types.Decimal, error) {
+	return fn(ctx, currency, date)
+}
+
+func TestClient_CreateInvoice_ExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newClient := func(t *testing.T, provider oblio.ExchangeRateProvider, sent ...*string) *oblio.Client {
+		t.Helper()
+
+		srv := obliotest.NewServer()
+		t.Cleanup(srv.Close)
+
+		httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost && req.URL.Path == "/docs/invoice" && len(sent) > 0 {
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				*sent[0] = string(body)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			return http.DefaultTransport.RoundTrip(req)
+		})}
+
+		return oblio.NewClient("client-id", "client-secret",
+			oblio.WithBaseURL(srv.URL), oblio.WithClient(httpClient), oblio.WithExchangeRates(provider))
+	}
+
+	newRequest := func(currency string, exchangeRate types.Decimal) *oblio.CreateInvoiceRequest {
+		return &oblio.CreateInvoiceRequest{
+			CIF:          obliotest.DefaultCIF,
+			SeriesName:   "FCT",
+			IssueDate:    types.NewDate(2024, 5, 6),
+			Currency:     currency,
+			ExchangeRate: exchangeRate,
+			Client:       types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+			},
+		}
+	}
+
+	provider := exchangeRatesFunc(func(_ context.Context, currency string, date types.Date) (types.Decimal, error) {
+		require.Equal(t, "EUR", currency)
+		require.Equal(t, types.NewDate(2024, 5, 6), date)
+
+		return "4.9765", nil
+	})
+
+	t.Run("filled for the issue date", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			req  = newRequest("EUR", "")
+			sent string
+		)
+
+		_, err := newClient(t, provider, &sent).CreateInvoice(ctx, req)
+		require.NoError(t, err)
+		require.Contains(t, sent, `"exchangeRate":"4.9765"`)
+		require.Empty(t, req.ExchangeRate)
+	})
+
+	t.Run("given rate kept", func(t *testing.T) {
+		t.Parallel()
+
+		req := newRequest("EUR", "5")
+
+		_, err := newClient(t, provider).CreateInvoice(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, types.Decimal("5"), req.ExchangeRate)
+	})
+
+	t.Run("ron", func(t *testing.T) {
+		t.Parallel()
+
+		req := newRequest("RON", "")
+
+		_, err := newClient(t, provider).CreateInvoice(ctx, req)
+		require.NoError(t, err)
+		require.Empty(t, req.ExchangeRate)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		t.Parallel()
+
+		wantErr := errors.New("feed unavailable")
+		client := newClient(t, exchangeRatesFunc(func(context.Context, string, types.Date) (types.Decimal, error) {
+			return "", wantErr
+		}))
+
+		_, err := client.CreateInvoice(ctx, newRequest("EUR", ""))
+		require.ErrorIs(t, err, wantErr)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(t, exchangeRatesFunc(func(context.Context, string, types.Date) (types.Decimal, error) {
+			require.Fail(t, "the rate of an invalid request is fetched")
+
+			return "", nil
+		}))
+
+		req := newRequest("EUR", "")
+		req.SeriesName = ""
+
+		_, err := client.CreateInvoice(ctx, req)
+		require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,271 @@
+package oblio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const defaultListConcurrency = 4
+
+// DefaultListInterval spaces the requests of ListInvoices to stay within the Oblio API limit of 30 requests
+// per 100 seconds.
+const DefaultListInterval = 100 * time.Second / 30
+
+type ListWindow string
+
+const (
+	DayListWindow   ListWindow = "day"
+	WeekListWindow  ListWindow = "week"
+	MonthListWindow ListWindow = "month"
+)
+
+type ListInvoicesRequest struct {
+	// GetInvoicesRequest filters the invoices; IssuedAfter and IssuedBefore are required, Offset is ignored and
+	// OrderBy and OrderDir must be empty, each window being paginated in id order.
+	GetInvoicesRequest
+
+	// Window is the span of issue dates fetched by one worker, a month by default.
+	Window ListWindow
+	// Concurrency is the number of windows fetched at the same time, 4 by default.
+	Concurrency int
+	// Interval is the minimum time between two requests of all the workers, to stay within the rate limit.
+	// It is DefaultListInterval when zero, while a negative one disables the pacing.
+	Interval time.Duration
+}
+
+func (r *ListInvoicesRequest) Validate() error {
+	errs := []error{r.GetInvoicesRequest.Validate()}
+
+	if r.IssuedAfter.IsZero() || r.IssuedBefore.IsZero() {
+		errs = append(errs, fmt.Errorf("issuedAfter and issuedBefore are required: %w", ErrInvalidArgument))
+	}
+
+	switch r.Window {
+	case "", DayListWindow, WeekListWindow, MonthListWindow:
+	default:
+		errs = append(errs, fmt.Errorf("window %q is unknown: %w", r.Window, ErrInvalidArgument))
+	}
+
+	if r.OrderBy != "" || r.OrderDir != "" {
+		errs = append(errs, fmt.Errorf("orderBy and orderDir must be empty, invoices being listed by issue date: %w",
+			ErrInvalidArgument))
+	}
+
+	if r.Concurrency < 0 {
+		errs = append(errs, fmt.Errorf("concurrency %d is negative: %w", r.Concurrency, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
+type ListInvoicesResponse struct {
+	// Data holds the invoices ordered by issue date, series and number, each listed once.
+	Data []types.Invoice
+}
+
+// ListInvoices fetches every invoice issued between IssuedAfter and IssuedBefore by splitting the range into
+// windows fetched concurrently, each paginated in id order so invoices added during the scan do not shift the
+// pages already read. The first error cancels the other windows.
+func (c *Client) ListInvoices(ctx context.Context, req *ListInvoicesRequest) (*ListInvoicesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		windows     = listWindows(req.IssuedAfter, req.IssuedBefore, req.Window)
+		concurrency = req.Concurrency
+		interval    = req.Interval
+		mu          sync.Mutex
+		seen        = make(map[string]struct{})
+		invoices    []types.Invoice
+		errs        = make(chan error, len(windows))
+		queue       = make(chan [2]types.Date, len(windows))
+		wg          sync.WaitGroup
+	)
+
+	if concurrency == 0 {
+		concurrency = defaultListConcurrency
+	}
+
+	if interval == 0 {
+		interval = DefaultListInterval
+	}
+
+	pace := newPacer(interval)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, window := range windows {
+		queue <- window
+	}
+
+	close(queue)
+
+	for range min(concurrency, len(windows)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for window := range queue {
+				found, err := c.listWindow(ctx, req.GetInvoicesRequest, window, pace)
+				if err != nil {
+					errs <- fmt.Errorf("window %s - %s: %w", window[0], window[1], err)
+					cancel()
+
+					return
+				}
+
+				mu.Lock()
+
+				for _, invoice := range found {
+					if _, ok := seen[invoice.ID]; ok && invoice.ID != "" {
+						continue
+					}
+
+					seen[invoice.ID] = struct{}{}
+					invoices = append(invoices, invoice)
+				}
+
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(invoices, compareInvoices)
+
+	return &ListInvoicesResponse{Data: invoices}, nil
+}
+
+func (c *Client) listWindow(
+	ctx context.Context, filter GetInvoicesRequest, window [2]types.Date, pace func(ctx context.Context) error,
+) ([]types.Invoice, error) {
+	filter.IssuedAfter, filter.IssuedBefore = window[0], window[1]
+	filter.Offset = 0
+	filter.OrderBy, filter.OrderDir = IDOrderBy, AscOrderDir
+
+	if filter.LimitPerPage == 0 {
+		filter.LimitPerPage = MaxLimitPerPage
+	}
+
+	it := NewIterator(ctx, func(ctx context.Context, offset int) ([]types.Invoice, error) {
+		if err := pace(ctx); err != nil {
+			return nil, err
+		}
+
+		req := filter
+		req.Offset = offset
+
+		resp, err := c.GetInvoices(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("getInvoices: %w", err)
+		}
+
+		return resp.Data, nil
+	}, WithPageSize(filter.LimitPerPage))
+	defer it.Close()
+
+	var invoices []types.Invoice
+
+	for it.Next() {
+		invoices = append(invoices, it.Value())
+	}
+
+	return invoices, it.Err()
+}
+
+// listWindows splits the issue dates from after to before, both included, in windows aligned to calendar days,
+// ISO weeks or months.
+func listWindows(after, before types.Date, window ListWindow) [][2]types.Date {
+	var windows [][2]types.Date
+
+	for start := after; !start.After(before); {
+		var end types.Date
+
+		switch window {
+		case DayListWindow:
+			end = start
+		case WeekListWindow:
+			end = start.AddDays((7 - int(start.Weekday())) % 7)
+		default:
+			end = start.EndOfMonth()
+		}
+
+		if end.After(before) {
+			end = before
+		}
+
+		windows = append(windows, [2]types.Date{start, end})
+		start = end.AddDays(1)
+	}
+
+	return windows
+}
+
+// compareInvoices orders invoices by issue date, series and number, shorter numbers first so that "9" comes
+// before "10".
+func compareInvoices(a, b types.Invoice) int {
+	if c := a.IssueDate.Compare(b.IssueDate); c != 0 {
+		return c
+	}
+
+	if c := strings.Compare(a.SeriesName, b.SeriesName); c != 0 {
+		return c
+	}
+
+	if c := len(a.Number) - len(b.Number); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Number, b.Number)
+}
+
+// newPacer returns a function spacing its callers by interval, blocking each until its turn comes.
+func newPacer(interval time.Duration) func(ctx context.Context) error {
+	var (
+		mu   sync.Mutex
+		next time.Time
+	)
+
+	return func(ctx context.Context) error {
+		if interval <= 0 {
+			return nil
+		}
+
+		mu.Lock()
+		now := time.Now()
+		at := now
+
+		if next.After(now) {
+			at = next
+		}
+
+		next = at.Add(interval)
+		mu.Unlock()
+
+		timer := time.NewTimer(at.Sub(now))
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package oblio_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestClient_ListInvoices(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newClient := func(t *testing.T) (*oblio.Client, *obliotest.Server) {
+		t.Helper()
+
+		srv := obliotest.NewServer()
+		t.Cleanup(srv.Close)
+
+		client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+
+		for _, date := range []types.Date{
+			types.NewDate(2024, 3, 5),
+			types.NewDate(2024, 1, 31),
+			types.NewDate(2024, 2, 1),
+			types.NewDate(2024, 1, 2),
+			types.NewDate(2024, 3, 5),
+			types.NewDate(2023, 12, 31),
+		} {
+			_, err := client.CreateInvoice(ctx, &oblio.CreateInvoiceRequest{
+				CIF:        obliotest.DefaultCIF,
+				SeriesName: "FCT",
+				IssueDate:  date,
+				Client:     types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+				Products: []types.DocumentRow{
+					&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+				},
+			})
+			require.NoError(t, err)
+		}
+
+		return client, srv
+	}
+
+	t.Run("ordered across windows", func(t *testing.T) {
+		t.Parallel()
+
+		for _, window := range []oblio.ListWindow{oblio.DayListWindow, oblio.WeekListWindow, oblio.MonthListWindow} {
+			client, _ := newClient(t)
+
+			got, err := client.ListInvoices(ctx, &oblio.ListInvoicesRequest{
+				GetInvoicesRequest: oblio.GetInvoicesRequest{
+					CIF:          obliotest.DefaultCIF,
+					IssuedAfter:  types.NewDate(2024, 1, 1),
+					IssuedBefore: types.NewDate(2024, 3, 31),
+					LimitPerPage: 1,
+				},
+				Window:      window,
+				Concurrency: 3,
+				Interval:    time.Millisecond,
+			})
+			require.NoError(t, err, window)
+
+			var issued []string
+
+			for _, invoice := range got.Data {
+				issued = append(issued, invoice.IssueDate.String()+" "+invoice.Number)
+			}
+
+			require.Equal(t, []string{
+				"2024-01-02 0004",
+				"2024-01-31 0002",
+				"2024-02-01 0003",
+				"2024-03-05 0001",
+				"2024-03-05 0005",
+			}, issued, window)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		client, srv := newClient(t)
+		srv.InjectError(obliotest.ErrorRule{Method: http.MethodGet, Path: "/docs/invoice/list", Status: 500, Times: 1})
+
+		_, err := client.ListInvoices(ctx, &oblio.ListInvoicesRequest{
+			GetInvoicesRequest: oblio.GetInvoicesRequest{
+				CIF:          obliotest.DefaultCIF,
+				IssuedAfter:  types.NewDate(2024, 1, 1),
+				IssuedBefore: types.NewDate(2024, 3, 31),
+			},
+		})
+		require.ErrorContains(t, err, "getInvoices")
+	})
+
+	t.Run("paced by default", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newClient(t)
+
+		ctx, cancel := context.WithTimeout(ctx, oblio.DefaultListInterval/4)
+		t.Cleanup(cancel)
+
+		_, err := client.ListInvoices(ctx, &oblio.ListInvoicesRequest{
+			GetInvoicesRequest: oblio.GetInvoicesRequest{
+				CIF:          obliotest.DefaultCIF,
+				IssuedAfter:  types.NewDate(2024, 1, 1),
+				IssuedBefore: types.NewDate(2024, 2, 29),
+			},
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newClient(t)
+
+		_, err := client.ListInvoices(ctx, &oblio.ListInvoicesRequest{
+			GetInvoicesRequest: oblio.GetInvoicesRequest{CIF: obliotest.DefaultCIF, OrderBy: oblio.NumberOrderBy},
+			Window:             "year",
+		})
+		require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+		require.ErrorContains(t, err, "issuedAfter and issuedBefore are required")
+		require.ErrorContains(t, err, `window "year"`)
+		require.ErrorContains(t, err, "orderBy and orderDir must be empty")
+	})
+}
@@ -0,0 +1,257 @@
+package oblio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+// PageFunc fetches the page of a list endpoint starting at offset.
+type PageFunc[T any] func(ctx context.Context, offset int) ([]T, error)
+
+type iteratorOptions struct {
+	offset   int
+	pageSize int
+	prefetch bool
+}
+
+type IteratorOption interface {
+	applyIterator(opts *iteratorOptions)
+}
+
+var _ IteratorOption = iteratorOptionFunc(nil)
+
+type iteratorOptionFunc func(opts *iteratorOptions)
+
+func (fn iteratorOptionFunc) applyIterator(opts *iteratorOptions) {
+	fn(opts)
+}
+
+// WithPrefetch fetches the next page in the background while the current one is being consumed.
+func WithPrefetch() IteratorOption {
+	return iteratorOptionFunc(func(opts *iteratorOptions) {
+		opts.prefetch = true
+	})
+}
+
+// WithPageSize tells the iterator how many items a full page holds, so it stops after a shorter page instead of
+// requesting an empty one.
+func WithPageSize(pageSize int) IteratorOption {
+	return iteratorOptionFunc(func(opts *iteratorOptions) {
+		opts.pageSize = pageSize
+	})
+}
+
+// WithOffset starts the iteration at offset.
+func WithOffset(offset int) IteratorOption {
+	return iteratorOptionFunc(func(opts *iteratorOptions) {
+		opts.offset = offset
+	})
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// Iterator walks every item of a paginated list endpoint, requesting pages as needed:
+//
+//	it := client.Invoices(ctx, &oblio.GetInvoicesRequest{CIF: cif})
+//	defer it.Close()
+//
+//	for it.Next() {
+//		invoice := it.Value()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//	}
+//
+// The iteration ends after an empty page or a page shorter than the page size, which is the largest page seen
+// so far unless given with WithPageSize. It is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetch    PageFunc[T]
+	prefetch bool
+	pageSize int
+	offset   int
+	page     []T
+	index    int
+	value    T
+	last     bool
+	pending  chan pageResult[T]
+	closed   bool
+	err      error
+}
+
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T], opts ...IteratorOption) *Iterator[T] {
+	options := &iteratorOptions{}
+
+	for _, opt := range opts {
+		opt.applyIterator(options)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Iterator[T]{
+		ctx:      ctx,
+		cancel:   cancel,
+		fetch:    fetch,
+		prefetch: options.prefetch,
+		pageSize: options.pageSize,
+		offset:   options.offset,
+	}
+}
+
+// Next advances to the next item and reports whether there is one. It returns false at the end of the list, on
+// error and once the context is canceled.
+func (it *Iterator[T]) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.last || !it.nextPage() {
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+
+	return true
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops a prefetch in progress. The iterator returns no more items afterwards.
+func (it *Iterator[T]) Close() {
+	it.cancel()
+	it.closed = true
+}
+
+func (it *Iterator[T]) nextPage() bool {
+	var res pageResult[T]
+
+	if it.pending != nil {
+		res = <-it.pending
+		it.pending = nil
+	} else {
+		res.items, res.err = it.fetch(it.ctx, it.offset)
+	}
+
+	if res.err != nil {
+		it.err = res.err
+
+		return false
+	}
+
+	it.page = res.items
+	it.index = 0
+	it.offset += len(res.items)
+	it.last = len(res.items) == 0 || len(res.items) < it.pageSize
+	it.pageSize = max(it.pageSize, len(res.items))
+
+	if it.prefetch && !it.last {
+		it.pending = make(chan pageResult[T], 1)
+
+		go func(ctx context.Context, offset int, pending chan<- pageResult[T]) {
+			items, err := it.fetch(ctx, offset)
+			pending <- pageResult[T]{items: items, err: err}
+		}(it.ctx, it.offset, it.pending)
+	}
+
+	return true
+}
+
+// Invoices iterates over the invoices matching filter, LimitPerPage defaulting to the largest page allowed. A
+// nil filter is a zero request.
+func (c *Client) Invoices(
+	ctx context.Context, filter *GetInvoicesRequest, opts ...IteratorOption,
+) *Iterator[types.Invoice] {
+	var req GetInvoicesRequest
+
+	if filter != nil {
+		req = *filter
+	}
+
+	if req.LimitPerPage == 0 {
+		req.LimitPerPage = MaxLimitPerPage
+	}
+
+	opts = append([]IteratorOption{WithOffset(req.Offset), WithPageSize(req.LimitPerPage)}, opts...)
+
+	return NewIterator(ctx, func(ctx context.Context, offset int) ([]types.Invoice, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetInvoices(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("getInvoices: %w", err)
+		}
+
+		return resp.Data, nil
+	}, opts...)
+}
+
+// Clients iterates over the clients matching filter, a nil filter being a zero request.
+func (c *Client) Clients(
+	ctx context.Context, filter *GetClientsRequest, opts ...IteratorOption,
+) *Iterator[types.Client] {
+	var req GetClientsRequest
+
+	if filter != nil {
+		req = *filter
+	}
+	opts = append([]IteratorOption{WithOffset(req.Offset)}, opts...)
+
+	return NewIterator(ctx, func(ctx context.Context, offset int) ([]types.Client, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetClients(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("getClients: %w", err)
+		}
+
+		return resp.Data, nil
+	}, opts...)
+}
+
+// Products iterates over the products matching filter, a nil filter being a zero request.
+func (c *Client) Products(
+	ctx context.Context, filter *GetProductsRequest, opts ...IteratorOption,
+) *Iterator[types.Product] {
+	var req GetProductsRequest
+
+	if filter != nil {
+		req = *filter
+	}
+	opts = append([]IteratorOption{WithOffset(req.Offset)}, opts...)
+
+	return NewIterator(ctx, func(ctx context.Context, offset int) ([]types.Product, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetProducts(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("getProducts: %w", err)
+		}
+
+		return resp.Data, nil
+	}, opts...)
+}
@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package oblio
+
+import (
+	"iter"
+)
+
+// All returns the items for a range loop, the error ending the iteration, if any, being yielded last with a zero
+// item. The iterator is closed when the loop ends.
+func (it *Iterator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer it.Close()
+
+		for it.Next() {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			var zero T
+
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package oblio_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+)
+
+func TestIterator_All(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		calls atomic.Int32
+		got   []int
+	)
+
+	for v, err := range oblio.NewIterator(ctx, pages(5, 2, &calls)).All() {
+		require.NoError(t, err)
+
+		if v == 3 {
+			break
+		}
+
+		got = append(got, v)
+	}
+
+	require.Equal(t, []int{0, 1, 2}, got)
+	require.EqualValues(t, 2, calls.Load())
+
+	wantErr := errors.New("boom")
+
+	for _, err := range oblio.NewIterator(ctx, func(context.Context, int) ([]int, error) {
+		return nil, wantErr
+	}).All() {
+		require.ErrorIs(t, err, wantErr)
+	}
+}
@@ -0,0 +1,187 @@
+package oblio_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func collect[T any](t *testing.T, it *oblio.Iterator[T]) []T {
+	t.Helper()
+
+	defer it.Close()
+
+	var items []T
+
+	for it.Next() {
+		items = append(items, it.Value())
+	}
+
+	require.NoError(t, it.Err())
+
+	return items
+}
+
+func pages(n, pageSize int, calls *atomic.Int32) oblio.PageFunc[int] {
+	return func(_ context.Context, offset int) ([]int, error) {
+		calls.Add(1)
+
+		var items []int
+
+		for i := offset; i < min(n, offset+pageSize); i++ {
+			items = append(items, i)
+		}
+
+		return items, nil
+	}
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("stops after a short page", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		got := collect(t, oblio.NewIterator(ctx, pages(5, 2, &calls)))
+		require.Equal(t, []int{0, 1, 2, 3, 4}, got)
+		require.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("stops after an empty page", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		got := collect(t, oblio.NewIterator(ctx, pages(4, 2, &calls)))
+		require.Equal(t, []int{0, 1, 2, 3}, got)
+		require.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("known page size", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		got := collect(t, oblio.NewIterator(ctx, pages(3, 2, &calls), oblio.WithPageSize(5)))
+		require.Equal(t, []int{0, 1}, got)
+		require.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("offset and prefetch", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		got := collect(t, oblio.NewIterator(ctx, pages(7, 2, &calls), oblio.WithOffset(1), oblio.WithPrefetch()))
+		require.Equal(t, []int{1, 2, 3, 4, 5, 6}, got)
+		require.EqualValues(t, 4, calls.Load())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		wantErr := errors.New("boom")
+		it := oblio.NewIterator(ctx, func(_ context.Context, offset int) ([]int, error) {
+			if offset > 0 {
+				return nil, wantErr
+			}
+
+			return []int{1, 2}, nil
+		}, oblio.WithPrefetch())
+		defer it.Close()
+
+		require.True(t, it.Next())
+		require.True(t, it.Next())
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), wantErr)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		ctx, cancel := context.WithCancel(ctx)
+		it := oblio.NewIterator(ctx, pages(10, 2, &calls))
+		defer it.Close()
+
+		require.True(t, it.Next())
+		cancel()
+		require.False(t, it.Next())
+		require.ErrorIs(t, it.Err(), context.Canceled)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		it := oblio.NewIterator(ctx, pages(10, 2, &calls))
+
+		require.True(t, it.Next())
+		it.Close()
+		require.False(t, it.Next())
+		require.NoError(t, it.Err())
+	})
+}
+
+func TestClient_Iterators(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := obliotest.NewServer(obliotest.WithPageSize(2))
+	t.Cleanup(srv.Close)
+
+	client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+
+	for i := range 5 {
+		srv.AddClient(obliotest.DefaultCIF, types.Client{Name: fmt.Sprintf("Client %d", i)})
+		srv.AddProduct(obliotest.DefaultCIF, types.Product{Name: fmt.Sprintf("Produs %d", i)})
+
+		_, err := client.CreateInvoice(ctx, &oblio.CreateInvoiceRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: "FCT",
+			Client:     types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	clients := collect(t, client.Clients(ctx, &oblio.GetClientsRequest{CIF: obliotest.DefaultCIF}))
+	require.Len(t, clients, 5)
+	require.Equal(t, "Client 4", clients[4].Name)
+
+	products := collect(t, client.Products(ctx, &oblio.GetProductsRequest{CIF: obliotest.DefaultCIF, Offset: 3}))
+	require.Len(t, products, 2)
+	require.Equal(t, "Produs 3", products[0].Name)
+
+	invoices := collect(t, client.Invoices(ctx, &oblio.GetInvoicesRequest{CIF: obliotest.DefaultCIF, LimitPerPage: 2},
+		oblio.WithPrefetch()))
+	require.Len(t, invoices, 5)
+}
+
+func TestClient_Iterators_NilFilter(t *testing.T) {
+	t.Parallel()
+
+	client := oblio.NewClient("client-id", "client-secret")
+
+	it := client.Invoices(context.Background(), nil)
+	defer it.Close()
+
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), oblio.ErrInvalidArgument)
+}
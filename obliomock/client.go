@@ -0,0 +1,545 @@
+package obliomock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+var _ oblio.API = (*Client)(nil)
+
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+type Call struct {
+	Method  string
+	Request any
+}
+
+type result struct {
+	resp any
+	err  error
+}
+
+// Client is a test double for oblio.API. Every call is recorded. A call is answered by its XxxFunc field when
+// set, otherwise by the results scripted with Return; unscripted calls fail with ErrUnexpectedCall.
+type Client struct {
+	GenerateTokenFunc          func(ctx context.Context) (*oblio.GenerateTokenResponse, error)
+	GetCompaniesFunc           func(ctx context.Context, req *oblio.GetCompaniesRequest) (*oblio.GetCompaniesResponse, error)
+	GetVATRatesFunc            func(ctx context.Context, req *oblio.GetVATRatesRequest) (*oblio.GetVATRatesResponse, error)
+	GetClientsFunc             func(ctx context.Context, req *oblio.GetClientsRequest) (*oblio.GetClientsResponse, error)
+	ResolveClientFunc          func(ctx context.Context, req *oblio.ResolveClientRequest) (*oblio.ResolveClientResponse, error)
+	UpsertClientFunc           func(ctx context.Context, req *oblio.ResolveClientRequest) (*oblio.ResolveClientResponse, error)
+	GetProductsFunc            func(ctx context.Context, req *oblio.GetProductsRequest) (*oblio.GetProductsResponse, error)
+	GetSeriesFunc              func(ctx context.Context, req *oblio.GetSeriesRequest) (*oblio.GetSeriesResponse, error)
+	GetLanguagesFunc           func(ctx context.Context, req *oblio.GetLanguagesRequest) (*oblio.GetLanguagesResponse, error)
+	GetManagementFunc          func(ctx context.Context, req *oblio.GetManagementRequest) (*oblio.GetManagementResponse, error)
+	InvalidateNomenclatureFunc func(ctx context.Context, cif string) error
+	WarmupNomenclatureFunc     func(ctx context.Context, cif string) error
+	PreflightFunc              func(ctx context.Context, req oblio.CreateDocumentRequest) error
+	CreateInvoiceFunc          func(ctx context.Context, req *oblio.CreateInvoiceRequest) (*oblio.CreateInvoiceResponse, error)
+	GetInvoiceFunc             func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	GetInvoicesFunc            func(ctx context.Context, req *oblio.GetInvoicesRequest) (*oblio.GetInvoicesResponse, error)
+	ListInvoicesFunc           func(ctx context.Context, req *oblio.ListInvoicesRequest) (*oblio.ListInvoicesResponse, error)
+	CancelInvoiceFunc          func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	RestoreInvoiceFunc         func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	DeleteInvoiceFunc          func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	CollectFunc                func(ctx context.Context, req *oblio.CollectRequest) (*oblio.CollectResponse, error)
+	CreateProformaFunc         func(ctx context.Context, req *oblio.CreateProformaRequest) (*oblio.CreateProformaResponse, error)
+	GetProformaFunc            func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	CancelProformaFunc         func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	RestoreProformaFunc        func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	DeleteProformaFunc         func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	CreateNoticeFunc           func(ctx context.Context, req *oblio.CreateNoticeRequest) (*oblio.CreateNoticeResponse, error)
+	GetNoticeFunc              func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	CancelNoticeFunc           func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	RestoreNoticeFunc          func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+	DeleteNoticeFunc           func(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error)
+
+	mu      sync.Mutex
+	calls   []Call
+	results map[string][]result
+}
+
+// scriptable holds the methods Return accepts, those having an XxxFunc field.
+var scriptable = scriptableMethods()
+
+func scriptableMethods() map[string]bool {
+	var (
+		t       = reflect.TypeFor[Client]()
+		methods = make(map[string]bool)
+	)
+
+	for i := range t.NumField() {
+		if name, ok := strings.CutSuffix(t.Field(i).Name, "Func"); ok {
+			methods[name] = true
+		}
+	}
+
+	return methods
+}
+
+func New() *Client {
+	return &Client{}
+}
+
+// Return scripts the response and error of the next call to method. Scripted results are consumed in order
+// and the last one keeps being returned once the others are used up. It panics when method is not a method of
+// oblio.API that can be scripted, e.g. a misspelled one.
+func (c *Client) Return(method string, resp any, err error) *Client {
+	if !scriptable[method] {
+		panic(fmt.Sprintf("obliomock: cannot script unknown method %q", method))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.results == nil {
+		c.results = make(map[string][]result)
+	}
+
+	c.results[method] = append(c.results[method], result{resp: resp, err: err})
+
+	return c
+}
+
+func (c *Client) ReturnError(method string, err error) *Client {
+	return c.Return(method, nil, err)
+}
+
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+func (c *Client) CallsTo(method string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var calls []Call
+
+	for _, call := range c.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = nil
+	c.results = nil
+}
+
+// record records a call and, unless an XxxFunc override answers it, takes its next scripted result.
+func (c *Client) record(method string, req any, overridden bool) (result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, Call{Method: method, Request: req})
+
+	if overridden {
+		return result{}, false
+	}
+
+	results := c.results[method]
+	if len(results) == 0 {
+		return result{}, false
+	}
+
+	if len(results) > 1 {
+		c.results[method] = results[1:]
+	}
+
+	return results[0], true
+}
+
+// remaining returns the number of scripted results of method left, the last one counting until Reset.
+func (c *Client) remaining(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.results[method])
+}
+
+// pages pages through a list method with fetch. Once the iterator has been handed the last scripted result of
+// method it ends with an empty page instead of getting that result again; direct calls to the method do not
+// count. Pages answered by an override are always fetched.
+func pages[T any](
+	c *Client, method string, overridden func() bool, fetch oblio.PageFunc[T],
+) oblio.PageFunc[T] {
+	var last bool
+
+	return func(ctx context.Context, offset int) ([]T, error) {
+		if overridden() {
+			return fetch(ctx, offset)
+		}
+
+		if last {
+			return nil, nil
+		}
+
+		last = c.remaining(method) <= 1
+
+		return fetch(ctx, offset)
+	}
+}
+
+func call[Resp any](c *Client, method string, req any, fn func() (Resp, error)) (Resp, error) {
+	var zero Resp
+
+	res, ok := c.record(method, req, fn != nil)
+
+	if fn != nil {
+		return fn()
+	}
+
+	if !ok {
+		return zero, fmt.Errorf("%s: %w", method, ErrUnexpectedCall)
+	}
+
+	if res.resp == nil {
+		return zero, res.err
+	}
+
+	resp, ok := res.resp.(Resp)
+	if !ok {
+		return zero, fmt.Errorf("%s: scripted response has type %T, want %T", method, res.resp, zero)
+	}
+
+	return resp, res.err
+}
+
+func callErr(c *Client, method string, req any, fn func() error) error {
+	res, ok := c.record(method, req, fn != nil)
+
+	if fn != nil {
+		return fn()
+	}
+
+	if !ok {
+		return fmt.Errorf("%s: %w", method, ErrUnexpectedCall)
+	}
+
+	return res.err
+}
+
+// stream hands items to fn in order, stopping at the first error like the streaming calls of oblio.Client.
+func stream[T any](status oblio.Status, items []T, fn func(item T) error) (*oblio.StreamResponse, error) {
+	resp := &oblio.StreamResponse{Status: status}
+
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return nil, err
+		}
+
+		resp.Count++
+	}
+
+	return resp, nil
+}
+
+func bind[Req, Resp any](ctx context.Context, req Req, fn func(context.Context, Req) (Resp, error)) func() (Resp, error) {
+	if fn == nil {
+		return nil
+	}
+
+	return func() (Resp, error) {
+		return fn(ctx, req)
+	}
+}
+
+func bindErr[Req any](ctx context.Context, req Req, fn func(context.Context, Req) error) func() error {
+	if fn == nil {
+		return nil
+	}
+
+	return func() error {
+		return fn(ctx, req)
+	}
+}
+
+func (c *Client) GenerateToken(ctx context.Context) (*oblio.GenerateTokenResponse, error) {
+	var fn func() (*oblio.GenerateTokenResponse, error)
+
+	if c.GenerateTokenFunc != nil {
+		fn = func() (*oblio.GenerateTokenResponse, error) {
+			return c.GenerateTokenFunc(ctx)
+		}
+	}
+
+	return call(c, "GenerateToken", nil, fn)
+}
+
+func (c *Client) GetCompanies(ctx context.Context, req *oblio.GetCompaniesRequest) (*oblio.GetCompaniesResponse, error) {
+	return call(c, "GetCompanies", req, bind(ctx, req, c.GetCompaniesFunc))
+}
+
+func (c *Client) GetVATRates(ctx context.Context, req *oblio.GetVATRatesRequest) (*oblio.GetVATRatesResponse, error) {
+	return call(c, "GetVATRates", req, bind(ctx, req, c.GetVATRatesFunc))
+}
+
+func (c *Client) GetClients(ctx context.Context, req *oblio.GetClientsRequest) (*oblio.GetClientsResponse, error) {
+	return call(c, "GetClients", req, bind(ctx, req, c.GetClientsFunc))
+}
+
+// Clients pages through GetClients like oblio.Client does. Once the scripted pages are used up it ends with an empty
+// page.
+func (c *Client) Clients(
+	ctx context.Context, filter *oblio.GetClientsRequest, opts ...oblio.IteratorOption,
+) *oblio.Iterator[types.Client] {
+	var req oblio.GetClientsRequest
+
+	if filter != nil {
+		req = *filter
+	}
+
+	opts = append([]oblio.IteratorOption{oblio.WithOffset(req.Offset)}, opts...)
+
+	overridden := func() bool {
+		return c.GetClientsFunc != nil
+	}
+
+	fetch := pages(c, "GetClients", overridden, func(ctx context.Context, offset int) ([]types.Client, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetClients(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+
+	return oblio.NewIterator(ctx, fetch, opts...)
+}
+
+// StreamClients hands the clients returned by GetClients to fn.
+func (c *Client) StreamClients(
+	ctx context.Context, req *oblio.GetClientsRequest, fn func(client types.Client) error,
+) (*oblio.StreamResponse, error) {
+	resp, err := c.GetClients(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream(resp.Status, resp.Data, fn)
+}
+
+func (c *Client) ResolveClient(
+	ctx context.Context, req *oblio.ResolveClientRequest,
+) (*oblio.ResolveClientResponse, error) {
+	return call(c, "ResolveClient", req, bind(ctx, req, c.ResolveClientFunc))
+}
+
+func (c *Client) UpsertClient(
+	ctx context.Context, req *oblio.ResolveClientRequest,
+) (*oblio.ResolveClientResponse, error) {
+	return call(c, "UpsertClient", req, bind(ctx, req, c.UpsertClientFunc))
+}
+
+func (c *Client) GetProducts(ctx context.Context, req *oblio.GetProductsRequest) (*oblio.GetProductsResponse, error) {
+	return call(c, "GetProducts", req, bind(ctx, req, c.GetProductsFunc))
+}
+
+// Products pages through GetProducts like oblio.Client does. Once the scripted pages are used up it ends with an empty
+// page.
+func (c *Client) Products(
+	ctx context.Context, filter *oblio.GetProductsRequest, opts ...oblio.IteratorOption,
+) *oblio.Iterator[types.Product] {
+	var req oblio.GetProductsRequest
+
+	if filter != nil {
+		req = *filter
+	}
+
+	opts = append([]oblio.IteratorOption{oblio.WithOffset(req.Offset)}, opts...)
+
+	overridden := func() bool {
+		return c.GetProductsFunc != nil
+	}
+
+	fetch := pages(c, "GetProducts", overridden, func(ctx context.Context, offset int) ([]types.Product, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetProducts(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+
+	return oblio.NewIterator(ctx, fetch, opts...)
+}
+
+// StreamProducts hands the products returned by GetProducts to fn.
+func (c *Client) StreamProducts(
+	ctx context.Context, req *oblio.GetProductsRequest, fn func(product types.Product) error,
+) (*oblio.StreamResponse, error) {
+	resp, err := c.GetProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream(resp.Status, resp.Data, fn)
+}
+
+func (c *Client) GetSeries(ctx context.Context, req *oblio.GetSeriesRequest) (*oblio.GetSeriesResponse, error) {
+	return call(c, "GetSeries", req, bind(ctx, req, c.GetSeriesFunc))
+}
+
+func (c *Client) GetLanguages(ctx context.Context, req *oblio.GetLanguagesRequest) (*oblio.GetLanguagesResponse, error) {
+	return call(c, "GetLanguages", req, bind(ctx, req, c.GetLanguagesFunc))
+}
+
+func (c *Client) GetManagement(ctx context.Context, req *oblio.GetManagementRequest) (*oblio.GetManagementResponse, error) {
+	return call(c, "GetManagement", req, bind(ctx, req, c.GetManagementFunc))
+}
+
+func (c *Client) InvalidateNomenclature(ctx context.Context, cif string) error {
+	return callErr(c, "InvalidateNomenclature", cif, bindErr(ctx, cif, c.InvalidateNomenclatureFunc))
+}
+
+func (c *Client) WarmupNomenclature(ctx context.Context, cif string) error {
+	return callErr(c, "WarmupNomenclature", cif, bindErr(ctx, cif, c.WarmupNomenclatureFunc))
+}
+
+func (c *Client) Preflight(ctx context.Context, req oblio.CreateDocumentRequest) error {
+	return callErr(c, "Preflight", req, bindErr(ctx, req, c.PreflightFunc))
+}
+
+func (c *Client) CreateInvoice(ctx context.Context, req *oblio.CreateInvoiceRequest) (*oblio.CreateInvoiceResponse, error) {
+	return call(c, "CreateInvoice", req, bind(ctx, req, c.CreateInvoiceFunc))
+}
+
+func (c *Client) GetInvoice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "GetInvoice", req, bind(ctx, req, c.GetInvoiceFunc))
+}
+
+func (c *Client) GetInvoices(ctx context.Context, req *oblio.GetInvoicesRequest) (*oblio.GetInvoicesResponse, error) {
+	return call(c, "GetInvoices", req, bind(ctx, req, c.GetInvoicesFunc))
+}
+
+// Invoices pages through GetInvoices like oblio.Client does. Once the scripted pages are used up it ends with an empty
+// page.
+func (c *Client) Invoices(
+	ctx context.Context, filter *oblio.GetInvoicesRequest, opts ...oblio.IteratorOption,
+) *oblio.Iterator[types.Invoice] {
+	var req oblio.GetInvoicesRequest
+
+	if filter != nil {
+		req = *filter
+	}
+
+	if req.LimitPerPage == 0 {
+		req.LimitPerPage = oblio.MaxLimitPerPage
+	}
+
+	opts = append([]oblio.IteratorOption{oblio.WithOffset(req.Offset), oblio.WithPageSize(req.LimitPerPage)}, opts...)
+
+	overridden := func() bool {
+		return c.GetInvoicesFunc != nil
+	}
+
+	fetch := pages(c, "GetInvoices", overridden, func(ctx context.Context, offset int) ([]types.Invoice, error) {
+		req := req
+		req.Offset = offset
+
+		resp, err := c.GetInvoices(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		return resp.Data, nil
+	})
+
+	return oblio.NewIterator(ctx, fetch, opts...)
+}
+
+// StreamInvoices hands the invoices returned by GetInvoices to fn.
+func (c *Client) StreamInvoices(
+	ctx context.Context, req *oblio.GetInvoicesRequest, fn func(invoice types.Invoice) error,
+) (*oblio.StreamResponse, error) {
+	resp, err := c.GetInvoices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream(resp.Status, resp.Data, fn)
+}
+
+func (c *Client) ListInvoices(
+	ctx context.Context, req *oblio.ListInvoicesRequest,
+) (*oblio.ListInvoicesResponse, error) {
+	return call(c, "ListInvoices", req, bind(ctx, req, c.ListInvoicesFunc))
+}
+
+func (c *Client) CancelInvoice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "CancelInvoice", req, bind(ctx, req, c.CancelInvoiceFunc))
+}
+
+func (c *Client) RestoreInvoice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "RestoreInvoice", req, bind(ctx, req, c.RestoreInvoiceFunc))
+}
+
+func (c *Client) DeleteInvoice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "DeleteInvoice", req, bind(ctx, req, c.DeleteInvoiceFunc))
+}
+
+func (c *Client) Collect(ctx context.Context, req *oblio.CollectRequest) (*oblio.CollectResponse, error) {
+	return call(c, "Collect", req, bind(ctx, req, c.CollectFunc))
+}
+
+func (c *Client) CreateProforma(ctx context.Context, req *oblio.CreateProformaRequest) (*oblio.CreateProformaResponse, error) {
+	return call(c, "CreateProforma", req, bind(ctx, req, c.CreateProformaFunc))
+}
+
+func (c *Client) GetProforma(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "GetProforma", req, bind(ctx, req, c.GetProformaFunc))
+}
+
+func (c *Client) CancelProforma(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "CancelProforma", req, bind(ctx, req, c.CancelProformaFunc))
+}
+
+func (c *Client) RestoreProforma(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "RestoreProforma", req, bind(ctx, req, c.RestoreProformaFunc))
+}
+
+func (c *Client) DeleteProforma(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "DeleteProforma", req, bind(ctx, req, c.DeleteProformaFunc))
+}
+
+func (c *Client) CreateNotice(ctx context.Context, req *oblio.CreateNoticeRequest) (*oblio.CreateNoticeResponse, error) {
+	return call(c, "CreateNotice", req, bind(ctx, req, c.CreateNoticeFunc))
+}
+
+func (c *Client) GetNotice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "GetNotice", req, bind(ctx, req, c.GetNoticeFunc))
+}
+
+func (c *Client) CancelNotice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "CancelNotice", req, bind(ctx, req, c.CancelNoticeFunc))
+}
+
+func (c *Client) RestoreNotice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "RestoreNotice", req, bind(ctx, req, c.RestoreNoticeFunc))
+}
+
+func (c *Client) DeleteNotice(ctx context.Context, req *oblio.DocumentRequest) (*oblio.DocumentResponse, error) {
+	return call(c, "DeleteNotice", req, bind(ctx, req, c.DeleteNoticeFunc))
+}
@@ -0,0 +1,176 @@
+package obliomock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliomock"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("scripted responses", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			first  = &oblio.GetSeriesResponse{Data: []types.Series{{Name: "A"}}}
+			second = &oblio.GetSeriesResponse{Data: []types.Series{{Name: "B"}}}
+			client = obliomock.New().
+				Return("GetSeries", first, nil).
+				Return("GetSeries", second, nil)
+			req = &oblio.GetSeriesRequest{CIF: "123"}
+		)
+
+		got, err := client.GetSeries(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, first, got)
+
+		for range 2 {
+			got, err = client.GetSeries(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, second, got)
+		}
+
+		require.Len(t, client.CallsTo("GetSeries"), 3)
+		require.Equal(t, obliomock.Call{Method: "GetSeries", Request: req}, client.Calls()[0])
+	})
+
+	t.Run("scripted error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			wantErr = errors.New("boom")
+			client  = obliomock.New().ReturnError("CreateInvoice", wantErr)
+		)
+
+		got, err := client.CreateInvoice(ctx, &oblio.CreateInvoiceRequest{})
+		require.ErrorIs(t, err, wantErr)
+		require.Nil(t, got)
+	})
+
+	t.Run("func field", func(t *testing.T) {
+		t.Parallel()
+
+		client := obliomock.New()
+		client.InvalidateNomenclatureFunc = func(_ context.Context, cif string) error {
+			require.Equal(t, "123", cif)
+
+			return nil
+		}
+
+		err := client.InvalidateNomenclature(ctx, "123")
+		require.NoError(t, err)
+		require.Len(t, client.Calls(), 1)
+	})
+
+	t.Run("func field keeps scripted results", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			scripted = &oblio.GetSeriesResponse{Data: []types.Series{{Name: "A"}}}
+			client   = obliomock.New().
+					Return("GetSeries", scripted, nil).
+					Return("GetSeries", &oblio.GetSeriesResponse{}, nil)
+			req = &oblio.GetSeriesRequest{CIF: "123"}
+		)
+
+		client.GetSeriesFunc = func(context.Context, *oblio.GetSeriesRequest) (*oblio.GetSeriesResponse, error) {
+			return &oblio.GetSeriesResponse{}, nil
+		}
+
+		_, err := client.GetSeries(ctx, req)
+		require.NoError(t, err)
+
+		client.GetSeriesFunc = nil
+
+		got, err := client.GetSeries(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, scripted, got)
+	})
+
+	t.Run("iterator ends once the script is used up", func(t *testing.T) {
+		t.Parallel()
+
+		client := obliomock.New().
+			Return("GetProducts", &oblio.GetProductsResponse{Data: []types.Product{{Name: "A"}, {Name: "B"}}}, nil).
+			Return("GetProducts", &oblio.GetProductsResponse{Data: []types.Product{{Name: "C"}}}, nil)
+
+		it := client.Products(ctx, nil)
+		defer it.Close()
+
+		var names []string
+
+		for it.Next() {
+			names = append(names, it.Value().Name)
+		}
+
+		require.NoError(t, it.Err())
+		require.Equal(t, []string{"A", "B", "C"}, names)
+	})
+
+	t.Run("iterator after a direct call", func(t *testing.T) {
+		t.Parallel()
+
+		client := obliomock.New().
+			Return("GetClients", &oblio.GetClientsResponse{Data: []types.Client{{Name: "A"}}}, nil)
+
+		_, err := client.GetClients(ctx, &oblio.GetClientsRequest{CIF: "123"})
+		require.NoError(t, err)
+
+		it := client.Clients(ctx, &oblio.GetClientsRequest{CIF: "123"})
+		defer it.Close()
+
+		require.True(t, it.Next())
+		require.Equal(t, "A", it.Value().Name)
+		require.False(t, it.Next())
+		require.NoError(t, it.Err())
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		t.Parallel()
+
+		require.PanicsWithValue(t, `obliomock: cannot script unknown method "GetInvoice2"`, func() {
+			obliomock.New().Return("GetInvoice2", nil, nil)
+		})
+	})
+
+	t.Run("invoices iterator uses the page size", func(t *testing.T) {
+		t.Parallel()
+
+		client := obliomock.New().
+			Return("GetInvoices", &oblio.GetInvoicesResponse{Data: []types.Invoice{{ID: "1"}}}, nil)
+
+		it := client.Invoices(ctx, &oblio.GetInvoicesRequest{CIF: "123"})
+		defer it.Close()
+
+		require.True(t, it.Next())
+		require.False(t, it.Next())
+		require.NoError(t, it.Err())
+		require.Len(t, client.CallsTo("GetInvoices"), 1)
+		require.Equal(t, oblio.MaxLimitPerPage,
+			client.CallsTo("GetInvoices")[0].Request.(*oblio.GetInvoicesRequest).LimitPerPage)
+	})
+
+	t.Run("unexpected call", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := obliomock.New().GetInvoice(ctx, &oblio.DocumentRequest{})
+		require.ErrorIs(t, err, obliomock.ErrUnexpectedCall)
+	})
+
+	t.Run("wrong response type", func(t *testing.T) {
+		t.Parallel()
+
+		client := obliomock.New().Return("GetInvoice", &oblio.GetSeriesResponse{}, nil)
+
+		_, err := client.GetInvoice(ctx, &oblio.DocumentRequest{})
+		require.Error(t, err)
+	})
+}
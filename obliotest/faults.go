@@ -0,0 +1,256 @@
+package obliotest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type FaultKind int
+
+const (
+	// NoFault forwards the request untouched. Use it to leave gaps in a FaultRule sequence.
+	NoFault FaultKind = iota
+	// LatencyFault waits for Delay before forwarding the request.
+	LatencyFault
+	// ConnectionResetFault fails the request with ECONNRESET without forwarding it.
+	ConnectionResetFault
+	// StatusFault answers with Status and an Oblio style JSON error body without forwarding the request.
+	StatusFault
+	// HTMLPageFault answers with Status, 502 by default, and an HTML error page, like a misbehaving proxy.
+	HTMLPageFault
+	// TruncatedJSONFault forwards the request and cuts the response body in half.
+	TruncatedJSONFault
+	// SlowBodyFault forwards the request and drips the response body one chunk every Delay.
+	SlowBodyFault
+)
+
+const slowBodyChunkSize = 16
+
+type Fault struct {
+	Kind    FaultKind
+	Status  int
+	Message string
+	Delay   time.Duration
+}
+
+// FaultRule selects the requests a fault applies to. Empty Method and Path match any request. When Sequence
+// is set, the n-th matching request gets the n-th fault and requests past the end are forwarded untouched.
+// Otherwise Fault is injected with the given Probability.
+type FaultRule struct {
+	Method      string
+	Path        string
+	Sequence    []Fault
+	Fault       Fault
+	Probability float64
+}
+
+type faultRule struct {
+	FaultRule
+
+	hits int
+}
+
+// FaultTransport is an http.RoundTripper that simulates Oblio misbehaving. Use it with
+// oblio.WithClient(&http.Client{Transport: transport}). The first matching rule decides the outcome of a
+// request; a fixed seed makes probabilistic rules reproducible.
+type FaultTransport struct {
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	rules []*faultRule
+	rand  *rand.Rand
+}
+
+func NewFaultTransport(base http.RoundTripper, seed int64, rules ...FaultRule) *FaultTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t := &FaultTransport{
+		base: base,
+		rand: rand.New(rand.NewSource(seed)),
+	}
+
+	for _, rule := range rules {
+		t.rules = append(t.rules, &faultRule{FaultRule: rule})
+	}
+
+	return t
+}
+
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.pick(req)
+
+	switch fault.Kind {
+	case LatencyFault:
+		if err := sleep(req.Context(), fault.Delay); err != nil {
+			closeBody(req)
+
+			return nil, err
+		}
+
+		return t.base.RoundTrip(req)
+
+	case ConnectionResetFault:
+		closeBody(req)
+
+		return nil, &net.OpError{
+			Op:  "read",
+			Net: "tcp",
+			Err: os.NewSyscallError("read", syscall.ECONNRESET),
+		}
+
+	case StatusFault:
+		closeBody(req)
+
+		body := fmt.Sprintf(`{"status":%d,"statusMessage":%q}`, fault.Status, fault.Message)
+		resp := newResponse(req, fault.Status, "application/json", body)
+
+		if fault.Status == http.StatusTooManyRequests {
+			resp.Header.Set("Retry-After", "1")
+		}
+
+		return resp, nil
+
+	case HTMLPageFault:
+		closeBody(req)
+
+		status := fault.Status
+		if status == 0 {
+			status = http.StatusBadGateway
+		}
+
+		body := fmt.Sprintf("<html><head><title>%d %s</title></head><body><h1>%s</h1></body></html>",
+			status, http.StatusText(status), http.StatusText(status))
+
+		return newResponse(req, status, "text/html; charset=UTF-8", body), nil
+
+	case TruncatedJSONFault:
+		return t.forwardWithBody(req, func(body []byte) io.Reader {
+			return bytes.NewReader(body[:len(body)/2])
+		})
+
+	case SlowBodyFault:
+		return t.forwardWithBody(req, func(body []byte) io.Reader {
+			return &slowReader{ctx: req.Context(), data: body, delay: fault.Delay}
+		})
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// closeBody closes the body of a request answered without reaching the base transport, as RoundTrip must.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+func (t *FaultTransport) pick(req *http.Request) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, rule := range t.rules {
+		if rule.Method != "" && rule.Method != req.Method {
+			continue
+		}
+
+		if rule.Path != "" && rule.Path != req.URL.Path {
+			continue
+		}
+
+		rule.hits++
+
+		if len(rule.Sequence) > 0 {
+			if rule.hits > len(rule.Sequence) {
+				return Fault{}
+			}
+
+			return rule.Sequence[rule.hits-1]
+		}
+
+		if t.rand.Float64() < rule.Probability {
+			return rule.Fault
+		}
+
+		return Fault{}
+	}
+
+	return Fault{}
+}
+
+func (t *FaultTransport) forwardWithBody(req *http.Request, fn func(body []byte) io.Reader) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("readAll: %w", err)
+	}
+
+	resp.Body = io.NopCloser(fn(body))
+	resp.ContentLength = -1
+	resp.Header.Del("Content-Length")
+
+	return resp, nil
+}
+
+func newResponse(req *http.Request, status int, contentType, body string) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{contentType}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+type slowReader struct {
+	ctx   context.Context
+	data  []byte
+	delay time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+
+	if err := sleep(r.ctx, r.delay); err != nil {
+		return 0, err
+	}
+
+	n := copy(p[:min(len(p), slowBodyChunkSize)], r.data)
+	r.data = r.data[n:]
+
+	return n, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
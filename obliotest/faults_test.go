@@ -0,0 +1,172 @@
+package obliotest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+)
+
+func NewFaultyClient(t *testing.T, seed int64, rules ...obliotest.FaultRule) *oblio.Client {
+	t.Helper()
+
+	srv := obliotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	transport := obliotest.NewFaultTransport(nil, seed, rules...)
+
+	return oblio.NewClient("client-id", "client-secret",
+		oblio.WithBaseURL(srv.URL), oblio.WithClient(&http.Client{Transport: transport}))
+}
+
+func TestFaultTransport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		path = "/nomenclature/languages"
+		req  = &oblio.GetLanguagesRequest{CIF: obliotest.DefaultCIF}
+	)
+
+	t.Run("sequence", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFaultyClient(t, 1, obliotest.FaultRule{
+			Path: path,
+			Sequence: []obliotest.Fault{
+				{Kind: obliotest.ConnectionResetFault},
+				{Kind: obliotest.StatusFault, Status: http.StatusTooManyRequests, Message: "slow down"},
+				{Kind: obliotest.HTMLPageFault},
+				{Kind: obliotest.TruncatedJSONFault},
+				{Kind: obliotest.NoFault},
+			},
+		})
+
+		_, err := client.GetLanguages(ctx, req)
+		require.ErrorIs(t, err, syscall.ECONNRESET)
+
+		var errResp *oblio.ErrorResponse
+
+		_, err = client.GetLanguages(ctx, req)
+		require.ErrorAs(t, err, &errResp)
+		require.Equal(t, http.StatusTooManyRequests, errResp.Status)
+		require.Equal(t, "slow down", errResp.Message)
+
+		_, err = client.GetLanguages(ctx, req)
+		require.ErrorAs(t, err, &errResp)
+		require.Equal(t, http.StatusBadGateway, errResp.Status)
+		require.Contains(t, errResp.Message, "<html>")
+
+		_, err = client.GetLanguages(ctx, req)
+		require.ErrorContains(t, err, "decode")
+
+		for range 2 {
+			_, err = client.GetLanguages(ctx, req)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("latency and slow body", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewFaultyClient(t, 1, obliotest.FaultRule{
+			Path: path,
+			Sequence: []obliotest.Fault{
+				{Kind: obliotest.LatencyFault, Delay: time.Millisecond * 50},
+				{Kind: obliotest.SlowBodyFault, Delay: time.Millisecond},
+			},
+		})
+
+		start := time.Now()
+
+		_, err := client.GetLanguages(ctx, req)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), time.Millisecond*50)
+
+		resp, err := client.GetLanguages(ctx, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Data)
+
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+
+		client = NewFaultyClient(t, 1, obliotest.FaultRule{
+			Path:        path,
+			Fault:       obliotest.Fault{Kind: obliotest.LatencyFault, Delay: time.Second},
+			Probability: 1,
+		})
+
+		_, err = client.GetLanguages(ctx, req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("probability is reproducible", func(t *testing.T) {
+		t.Parallel()
+
+		run := func() []bool {
+			client := NewFaultyClient(t, 42, obliotest.FaultRule{
+				Method:      http.MethodGet,
+				Path:        path,
+				Fault:       obliotest.Fault{Kind: obliotest.StatusFault, Status: http.StatusServiceUnavailable},
+				Probability: 0.5,
+			})
+
+			var out []bool
+
+			for range 20 {
+				_, err := client.GetLanguages(ctx, req)
+				out = append(out, err != nil)
+			}
+
+			return out
+		}
+
+		first := run()
+		require.Equal(t, first, run())
+		require.Contains(t, first, true)
+		require.Contains(t, first, false)
+	})
+
+	t.Run("closes the body of faked requests", func(t *testing.T) {
+		t.Parallel()
+
+		for _, kind := range []obliotest.FaultKind{
+			obliotest.ConnectionResetFault, obliotest.StatusFault, obliotest.HTMLPageFault,
+		} {
+			transport := obliotest.NewFaultTransport(nil, 1, obliotest.FaultRule{
+				Fault:       obliotest.Fault{Kind: kind, Status: http.StatusServiceUnavailable},
+				Probability: 1,
+			})
+			body := &closeRecorder{Reader: strings.NewReader("{}")}
+
+			httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://oblio.test/api", body)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(httpReq)
+			if err == nil {
+				require.NoError(t, resp.Body.Close())
+			}
+
+			require.True(t, body.closed, kind)
+		}
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+
+	return nil
+}
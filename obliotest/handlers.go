@@ -0,0 +1,712 @@
+package obliotest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const maxInvoicesPerPage = 100
+
+type response struct {
+	Status        int    `json:"status"`
+	StatusMessage string `json:"statusMessage"`
+	Data          any    `json:"data,omitempty"`
+}
+
+type tokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   string `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+	RequestTime string `json:"request_time"`
+}
+
+type documentRequest struct {
+	CIF        string `json:"cif"`
+	SeriesName string `json:"seriesName"`
+	Number     string `json:"number"`
+}
+
+type collectRequest struct {
+	documentRequest
+
+	Collects []types.Collect `json:"collects"`
+}
+
+type documentRow struct {
+	Name             string             `json:"name"`
+	MeasuringUnit    string             `json:"measuringUnit"`
+	Price            types.Decimal      `json:"price"`
+	Quantity         types.Decimal      `json:"quantity"`
+	VATName          string             `json:"vatName"`
+	VATPercentage    types.Decimal      `json:"vatPercentage"`
+	VATIncluded      *types.Bool        `json:"vatIncluded"`
+	RefItem          string             `json:"refItem"`
+	Discount         types.Decimal      `json:"discount"`
+	DiscountType     types.DiscountType `json:"discountType"`
+	DiscountAllAbove types.Bool         `json:"discountAllAbove"`
+}
+
+type createDocumentRequest struct {
+	CIF        string        `json:"cif"`
+	Client     types.Client  `json:"client"`
+	IssueDate  types.Date    `json:"issueDate"`
+	DueDate    types.Date    `json:"dueDate"`
+	SeriesName string        `json:"seriesName"`
+	Precision  types.Int     `json:"precision"`
+	Currency   string        `json:"currency"`
+	Products   []documentRow `json:"products"`
+	IssuerName string        `json:"issuerName"`
+	Mentions   string        `json:"mentions"`
+	Collect    types.Collect `json:"collect"`
+	UseStock   types.Bool    `json:"useStock"`
+}
+
+// isDiscount tells discount rows apart from lines carrying their own discount.
+func (r documentRow) isDiscount() bool {
+	return r.DiscountType != "" && r.Price.IsEmpty()
+}
+
+// priceIncludesVAT reports whether the row price includes VAT, which Oblio assumes when vatIncluded is missing.
+func (r documentRow) priceIncludesVAT() bool {
+	return r.VATIncluded == nil || bool(*r.VATIncluded)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /authorize/token", s.handleToken)
+	mux.HandleFunc("GET /nomenclature/{name}", s.authorized(s.handleNomenclature))
+	mux.HandleFunc("GET /docs/invoice/list", s.authorized(s.handleListInvoices))
+	mux.HandleFunc("PUT /docs/invoice/collect", s.authorized(s.handleCollect))
+	mux.HandleFunc("POST /docs/{kind}", s.authorized(s.handleCreateDocument))
+	mux.HandleFunc("GET /docs/{kind}", s.authorized(s.handleGetDocument))
+	mux.HandleFunc("PUT /docs/{kind}/cancel", s.authorized(s.handleCancelDocument))
+	mux.HandleFunc("PUT /docs/{kind}/restore", s.authorized(s.handleRestoreDocument))
+	mux.HandleFunc("DELETE /docs/{kind}", s.authorized(s.handleDeleteDocument))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rule := s.matchErrorRule(r); rule != nil {
+			writeError(w, rule.Status, rule.Message)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		s.mu.Lock()
+		_, ok := s.tokens[token]
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Invalid access token")
+
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	req := tokenRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	if req.ClientID == "" || req.ClientSecret == "" {
+		writeError(w, http.StatusBadRequest, "client_id and client_secret are required")
+
+		return
+	}
+
+	if s.clientID != "" && (req.ClientID != s.clientID || req.ClientSecret != s.clientSecret) {
+		writeError(w, http.StatusUnauthorized, "Invalid client credentials")
+
+		return
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	s.tokens[token] = struct{}{}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken: token,
+		ExpiresIn:   "3600",
+		TokenType:   "Bearer",
+		RequestTime: strconv.FormatInt(time.Now().Unix(), 10),
+	})
+}
+
+func (s *Server) handleNomenclature(w http.ResponseWriter, r *http.Request) {
+	var (
+		name = r.PathValue("name")
+		q    = r.URL.Query()
+	)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == "companies" {
+		companies := make([]types.Company, 0, len(s.companies))
+
+		for _, c := range s.companies {
+			companies = append(companies, c.info)
+		}
+
+		writeData(w, companies)
+
+		return
+	}
+
+	c := s.company(q.Get("cif"))
+	if c == nil {
+		writeError(w, http.StatusBadRequest, "Firma nu exista")
+
+		return
+	}
+
+	switch name {
+	case "vat_rates":
+		writeData(w, c.vatRates)
+	case "series":
+		writeData(w, c.series)
+	case "languages":
+		writeData(w, c.languages)
+	case "management":
+		writeData(w, c.management)
+	case "clients":
+		clients := filter(c.clients, func(client types.Client) bool {
+			return contains(client.Name, q.Get("name")) && matches(client.CIF, q.Get("clientCif"))
+		})
+
+		writeData(w, page(clients, q.Get("offset"), s.pageSize))
+	case "products":
+		products := filter(c.products, func(product types.Product) bool {
+			return contains(product.Name, q.Get("name")) &&
+				matches(product.Code, q.Get("code")) &&
+				hasStock(product, q.Get("management"), q.Get("workStation"))
+		})
+
+		writeData(w, page(products, q.Get("offset"), s.pageSize))
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	kind := r.PathValue("kind")
+	if _, ok := seriesTypes[kind]; !ok {
+		writeError(w, http.StatusNotFound, "Not found")
+
+		return
+	}
+
+	req := createDocumentRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.company(req.CIF)
+	if c == nil {
+		writeError(w, http.StatusBadRequest, "Firma nu exista")
+
+		return
+	}
+
+	if req.Client.Name == "" && req.Client.CIF == "" {
+		writeError(w, http.StatusBadRequest, "Clientul este obligatoriu")
+
+		return
+	}
+
+	if len(req.Products) == 0 {
+		writeError(w, http.StatusBadRequest, "Produsele sunt obligatorii")
+
+		return
+	}
+
+	series := c.findSeries(kind, req.SeriesName)
+	if series == nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Seria %s nu exista", req.SeriesName))
+
+		return
+	}
+
+	number, err := strconv.Atoi(series.Next)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+
+		return
+	}
+
+	issueDate := req.IssueDate
+	if issueDate.IsZero() {
+		issueDate = types.Today()
+	}
+
+	doc := &document{
+		kind: kind,
+		invoice: types.Invoice{
+			ID:         s.nextID(),
+			SeriesName: series.Name,
+			Number:     series.Next,
+			IssueDate:  issueDate,
+			DueDate:    req.DueDate,
+			Precision:  req.Precision,
+			Currency:   req.Currency,
+			Total:      total(req.Products).Round(types.SimplePrecision),
+			IssuerName: req.IssuerName,
+			Mentions:   req.Mentions,
+			UseStock:   req.UseStock,
+			Type:       series.Type,
+			Client:     req.Client,
+			Products:   products(req.Products),
+		},
+	}
+
+	if doc.invoice.Currency == "" {
+		doc.invoice.Currency = "RON"
+	}
+
+	doc.invoice.Link = s.URL + "/utils/show_file/?id=" + doc.invoice.ID
+
+	if kind == invoiceKind && req.Collect.Type != "" {
+		doc.invoice.Collected = true
+		doc.collects = append(doc.collects, req.Collect)
+	}
+
+	if req.Client.Save && c.findClient(req.Client.CIF) == nil {
+		c.clients = append(c.clients, req.Client)
+	}
+
+	series.Next = fmt.Sprintf("%0*d", len(series.Next), number+1)
+	c.documents = append(c.documents, doc)
+
+	writeData(w, doc.toDocument())
+}
+
+func (s *Server) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.withDocument(w, r.PathValue("kind"), documentRequest{
+		CIF:        q.Get("cif"),
+		SeriesName: q.Get("seriesName"),
+		Number:     q.Get("number"),
+	}, func(_ *company, doc *document) {
+		writeData(w, doc.toDocument())
+	})
+}
+
+func (s *Server) handleCancelDocument(w http.ResponseWriter, r *http.Request) {
+	s.withDocumentBody(w, r, func(_ *company, doc *document) {
+		if doc.invoice.Canceled {
+			writeError(w, http.StatusBadRequest, "Documentul este deja anulat")
+
+			return
+		}
+
+		doc.invoice.Canceled = true
+
+		writeData(w, doc.toDocument())
+	})
+}
+
+func (s *Server) handleRestoreDocument(w http.ResponseWriter, r *http.Request) {
+	s.withDocumentBody(w, r, func(_ *company, doc *document) {
+		if !doc.invoice.Canceled {
+			writeError(w, http.StatusBadRequest, "Documentul nu este anulat")
+
+			return
+		}
+
+		doc.invoice.Canceled = false
+
+		writeData(w, doc.toDocument())
+	})
+}
+
+func (s *Server) handleDeleteDocument(w http.ResponseWriter, r *http.Request) {
+	s.withDocumentBody(w, r, func(c *company, doc *document) {
+		series := c.findSeries(doc.kind, doc.invoice.SeriesName)
+		if series == nil {
+			writeError(w, http.StatusBadRequest, "Seria nu exista")
+
+			return
+		}
+
+		number, _ := strconv.Atoi(doc.invoice.Number)
+		next, _ := strconv.Atoi(series.Next)
+
+		if number != next-1 {
+			writeError(w, http.StatusBadRequest, "Doar ultimul document din serie poate fi sters")
+
+			return
+		}
+
+		c.documents = slices.DeleteFunc(c.documents, func(d *document) bool {
+			return d == doc
+		})
+		series.Next = doc.invoice.Number
+
+		writeData(w, doc.toDocument())
+	})
+}
+
+func (s *Server) handleCollect(w http.ResponseWriter, r *http.Request) {
+	req := collectRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.withDocument(w, invoiceKind, req.documentRequest, func(_ *company, doc *document) {
+		if len(req.Collects) == 0 {
+			writeError(w, http.StatusBadRequest, "Incasarea este obligatorie")
+
+			return
+		}
+
+		if doc.invoice.Canceled {
+			writeError(w, http.StatusBadRequest, "Factura este anulata")
+
+			return
+		}
+
+		doc.collects = append(doc.collects, req.Collects...)
+		doc.invoice.Collected = true
+
+		writeData(w, doc.toDocument())
+	})
+}
+
+func (s *Server) handleListInvoices(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.company(q.Get("cif"))
+	if c == nil {
+		writeError(w, http.StatusBadRequest, "Firma nu exista")
+
+		return
+	}
+
+	issuedAfter, err := parseDate(q.Get("issuedAfter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	issuedBefore, err := parseDate(q.Get("issuedBefore"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	var invoices []types.Invoice
+
+	for _, doc := range c.documents {
+		inv := doc.invoice
+
+		if doc.kind != invoiceKind ||
+			!matches(inv.SeriesName, q.Get("seriesName")) ||
+			!matches(inv.Number, q.Get("number")) ||
+			!matchesBool(inv.Draft, q.Get("draft")) ||
+			!matchesBool(inv.Canceled, q.Get("canceled")) ||
+			!matches(inv.Client.CIF, q.Get("client[cif]")) ||
+			!matches(inv.Client.Email, q.Get("client[email]")) ||
+			!matches(inv.Client.Phone, q.Get("client[phone]")) ||
+			!matches(inv.Client.Code, q.Get("client[code]")) ||
+			!issuedAfter.IsZero() && inv.IssueDate.Before(issuedAfter) ||
+			!issuedBefore.IsZero() && inv.IssueDate.After(issuedBefore) {
+			continue
+		}
+
+		if q.Get("withProducts") != "1" {
+			inv.Products = nil
+		}
+
+		invoices = append(invoices, inv)
+	}
+
+	sortInvoices(invoices, q.Get("orderBy"), q.Get("orderDir"))
+
+	limit, _ := strconv.Atoi(q.Get("limitPerPage"))
+	if limit <= 0 || limit > maxInvoicesPerPage {
+		limit = maxInvoicesPerPage
+	}
+
+	writeData(w, page(invoices, q.Get("offset"), limit))
+}
+
+func (s *Server) withDocumentBody(w http.ResponseWriter, r *http.Request, fn func(c *company, doc *document)) {
+	req := documentRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	s.withDocument(w, r.PathValue("kind"), req, fn)
+}
+
+func (s *Server) withDocument(
+	w http.ResponseWriter, kind string, req documentRequest, fn func(c *company, doc *document),
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.company(req.CIF)
+	if c == nil {
+		writeError(w, http.StatusBadRequest, "Firma nu exista")
+
+		return
+	}
+
+	for _, doc := range c.documents {
+		if doc.kind == kind && doc.invoice.SeriesName == req.SeriesName && doc.invoice.Number == req.Number {
+			fn(c, doc)
+
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Documentul nu exista")
+}
+
+func (c *company) findSeries(kind, name string) *types.Series {
+	for i := range c.series {
+		if c.series[i].Type == seriesTypes[kind] && c.series[i].Name == name {
+			return &c.series[i]
+		}
+	}
+
+	return nil
+}
+
+func (c *company) findClient(cif string) *types.Client {
+	for i := range c.clients {
+		if cif != "" && c.clients[i].CIF == cif {
+			return &c.clients[i]
+		}
+	}
+
+	return nil
+}
+
+func (d *document) toDocument() types.Document {
+	return types.Document{
+		DocumentType: seriesTypes[d.kind],
+		SeriesName:   d.invoice.SeriesName,
+		Number:       d.invoice.Number,
+		Link:         d.invoice.Link,
+		Total:        d.invoice.Total,
+		Collects:     d.collects,
+	}
+}
+
+func total(rows []documentRow) types.Decimal {
+	var (
+		sum   = types.NewDecimalFromInt(0)
+		items = make(map[string]types.Decimal)
+	)
+
+	for _, row := range rows {
+		if !row.isDiscount() {
+			value := row.Price.Mul(row.Quantity)
+			if !row.priceIncludesVAT() {
+				value = value.Add(value.Mul(row.VATPercentage.Mul("0.01")))
+			}
+
+			value = value.Sub(discount(value, row.Discount, row.DiscountType))
+
+			items[row.Name] = value
+			sum = sum.Add(value)
+
+			continue
+		}
+
+		base := items[row.RefItem]
+		if row.DiscountAllAbove {
+			base = sum
+		}
+
+		sum = sum.Sub(discount(base, row.Discount, row.DiscountType))
+	}
+
+	return sum
+}
+
+func discount(base, value types.Decimal, discountType types.DiscountType) types.Decimal {
+	switch discountType {
+	case types.PercentageDiscountType:
+		return base.Mul(value).Mul("0.01").Round(types.DoublePrecision)
+	case types.FlatDiscountType:
+		return value
+	}
+
+	return types.NewDecimalFromInt(0)
+}
+
+func products(rows []documentRow) []types.Product {
+	var out []types.Product
+
+	for _, row := range rows {
+		if row.isDiscount() {
+			continue
+		}
+
+		out = append(out, types.Product{
+			Name:          row.Name,
+			MeasuringUnit: row.MeasuringUnit,
+			Price:         row.Price,
+			VATName:       row.VATName,
+			VATPercentage: row.VATPercentage,
+			VATIncluded:   types.Bool(row.priceIncludesVAT()),
+		})
+	}
+
+	return out
+}
+
+func sortInvoices(invoices []types.Invoice, orderBy, orderDir string) {
+	slices.SortStableFunc(invoices, func(a, b types.Invoice) int {
+		var c int
+
+		switch orderBy {
+		case "issueDate":
+			c = a.IssueDate.Compare(b.IssueDate)
+		case "number":
+			c = strings.Compare(a.SeriesName+a.Number, b.SeriesName+b.Number)
+		default:
+			x, _ := strconv.Atoi(a.ID)
+			y, _ := strconv.Atoi(b.ID)
+			c = x - y
+		}
+
+		if strings.EqualFold(orderDir, "DESC") {
+			return -c
+		}
+
+		return c
+	})
+}
+
+func filter[T any](items []T, fn func(T) bool) []T {
+	out := make([]T, 0, len(items))
+
+	for _, item := range items {
+		if fn(item) {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+func page[T any](items []T, rawOffset string, limit int) []T {
+	offset, _ := strconv.Atoi(rawOffset)
+
+	if offset < 0 || offset >= len(items) {
+		return []T{}
+	}
+
+	return items[offset:min(offset+limit, len(items))]
+}
+
+func hasStock(product types.Product, management, workStation string) bool {
+	if management == "" && workStation == "" {
+		return true
+	}
+
+	return slices.ContainsFunc(product.Stock, func(stock types.Stock) bool {
+		return matches(stock.Management, management) && matches(stock.WorkStation, workStation)
+	})
+}
+
+func contains(value, sub string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(sub))
+}
+
+func matches(value, want string) bool {
+	return want == "" || value == want
+}
+
+func matchesBool(value types.Bool, want string) bool {
+	return want == "" || bool(value) == (want == "1")
+}
+
+func parseDate(s string) (types.Date, error) {
+	if s == "" {
+		return types.Date{}, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return types.Date{}, fmt.Errorf("invalid date %q", s)
+	}
+
+	return types.NewDate(t.Year(), int(t.Month()), t.Day()), nil
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	writeJSON(w, http.StatusOK, response{
+		Status:        http.StatusOK,
+		StatusMessage: "Success",
+		Data:          data,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, response{
+		Status:        status,
+		StatusMessage: message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,340 @@
+package obliotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const CassetteVersion = 1
+
+const redacted = "[REDACTED]"
+
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// secretKeys are the JSON keys whose values are replaced before an interaction is written to a cassette.
+var secretKeys = map[string]struct{}{
+	"client_id":     {},
+	"client_secret": {},
+	"access_token":  {},
+}
+
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+type Mode int
+
+const (
+	// ModeReplay serves every request from the cassette and fails on requests it cannot match.
+	ModeReplay Mode = iota
+	// ModeRecord forwards every request to the real transport and overwrites the cassette on Stop.
+	ModeRecord
+	// ModeAuto records when the cassette file does not exist yet and replays otherwise.
+	ModeAuto
+)
+
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records real interactions into a cassette file and replays them.
+// Use it with oblio.WithClient(recorder.Client()).
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+type recorderOptions struct {
+	transport http.RoundTripper
+}
+
+type RecorderOption interface {
+	apply(opts *recorderOptions)
+}
+
+var _ RecorderOption = recorderOptionFunc(nil)
+
+type recorderOptionFunc func(opts *recorderOptions)
+
+func (fn recorderOptionFunc) apply(opts *recorderOptions) {
+	fn(opts)
+}
+
+// WithTransport sets the transport used to reach the real API while recording.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return recorderOptionFunc(func(opts *recorderOptions) {
+		opts.transport = transport
+	})
+}
+
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	options := &recorderOptions{
+		transport: http.DefaultTransport,
+	}
+
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: options.transport,
+		cassette: Cassette{
+			Version: CassetteVersion,
+		},
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeRecord
+
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode == ModeRecord {
+		return r, nil
+	}
+
+	if err := r.load(); err != nil {
+		return nil, fmt.Errorf("load: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	return r.record(req, recorded)
+}
+
+// Stop writes the recorded interactions to the cassette file. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalIndent: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("mkdirAll: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writeFile: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) load() error {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("readFile: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	if r.cassette.Version != CassetteVersion {
+		return fmt.Errorf("unsupported cassette version %d", r.cassette.Version)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return nil
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("readAll: %w", err)
+	}
+
+	header := resp.Header.Clone()
+
+	for _, key := range secretHeaders {
+		header.Del(key)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   scrubBody(body),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request. Once all the matching interactions have
+// been used, the last one is served again, so repeated identical calls keep working.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+
+		match = i
+
+		if !r.used[i] {
+			break
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("%s %s?%s %s: %w", recorded.Method, recorded.Path, recorded.Query, recorded.Body, ErrNoInteraction)
+	}
+
+	r.used[match] = true
+	resp := r.cassette.Interactions[match].Response
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+		StatusCode:    resp.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(resp.Body))),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+func newRecordedRequest(req *http.Request) (RecordedRequest, error) {
+	var body []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return RecordedRequest{}, fmt.Errorf("readAll: %w", err)
+		}
+
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	query, err := url.ParseQuery(req.URL.RawQuery)
+	if err != nil {
+		return RecordedRequest{}, fmt.Errorf("parseQuery: %w", err)
+	}
+
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query.Encode(),
+		Body:   scrubBody(body),
+	}, nil
+}
+
+// scrubBody replaces secrets in JSON bodies and normalizes them, so semantically equal bodies match on replay.
+// Other bodies are returned unchanged.
+func scrubBody(body []byte) string {
+	var v any
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	data, err := json.Marshal(scrub(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+func scrub(v any) any {
+	switch a := v.(type) {
+	case map[string]any:
+		for key, value := range a {
+			if _, ok := secretKeys[key]; ok {
+				a[key] = redacted
+
+				continue
+			}
+
+			a[key] = scrub(value)
+		}
+	case []any:
+		for i := range a {
+			a[i] = scrub(a[i])
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,79 @@
+package obliotest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		cassette = filepath.Join(t.TempDir(), "cassettes", "series.json")
+		req      = &oblio.GetSeriesRequest{CIF: obliotest.DefaultCIF}
+	)
+
+	srv := obliotest.NewServer()
+
+	recorder, err := obliotest.NewRecorder(cassette, obliotest.ModeAuto)
+	require.NoError(t, err)
+	require.Equal(t, obliotest.ModeRecord, recorder.Mode())
+
+	client := oblio.NewClient("client-id", "client-secret",
+		oblio.WithBaseURL(srv.URL), oblio.WithClient(recorder.Client()))
+
+	want, err := client.GetSeries(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Stop())
+
+	srv.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "client-secret")
+	require.Contains(t, string(data), "[REDACTED]")
+
+	t.Run("replay", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, err := obliotest.NewRecorder(cassette, obliotest.ModeAuto)
+		require.NoError(t, err)
+		require.Equal(t, obliotest.ModeReplay, recorder.Mode())
+
+		client := oblio.NewClient("other-id", "other-secret",
+			oblio.WithBaseURL(srv.URL), oblio.WithClient(recorder.Client()))
+
+		for range 2 {
+			got, err := client.GetSeries(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, want, got)
+		}
+	})
+
+	t.Run("unmatched request", func(t *testing.T) {
+		t.Parallel()
+
+		recorder, err := obliotest.NewRecorder(cassette, obliotest.ModeReplay)
+		require.NoError(t, err)
+
+		client := oblio.NewClient("client-id", "client-secret",
+			oblio.WithBaseURL(srv.URL), oblio.WithClient(recorder.Client()))
+
+		_, err = client.GetSeries(ctx, &oblio.GetSeriesRequest{CIF: "RO1"})
+		require.ErrorIs(t, err, obliotest.ErrNoInteraction)
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := obliotest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), obliotest.ModeReplay)
+		require.Error(t, err)
+	})
+}
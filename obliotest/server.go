@@ -0,0 +1,297 @@
+package obliotest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const (
+	DefaultCIF      = "RO12345674"
+	DefaultPageSize = 250
+
+	invoiceKind  = "invoice"
+	proformaKind = "proforma"
+	noticeKind   = "notice"
+)
+
+var seriesTypes = map[string]types.DocumentType{
+	invoiceKind:  types.InvoiceDocumentType,
+	proformaKind: types.ProformaDocumentType,
+	noticeKind:   types.NoticeDocumentType,
+}
+
+// ErrorRule makes the server answer matching requests with an error instead of handling them. Empty Method
+// and Path match any request. Times limits how many requests are failed; zero fails every matching request.
+type ErrorRule struct {
+	Method  string
+	Path    string
+	Status  int
+	Message string
+	Times   int
+}
+
+type document struct {
+	kind     string
+	invoice  types.Invoice
+	collects []types.Collect
+}
+
+type company struct {
+	info       types.Company
+	vatRates   []types.VATRate
+	clients    []types.Client
+	products   []types.Product
+	series     []types.Series
+	languages  []types.Language
+	management []types.Management
+	documents  []*document
+}
+
+// Server is an in-process fake of the Oblio API backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	clientID     string
+	clientSecret string
+	pageSize     int
+
+	mu         sync.Mutex
+	companies  []*company
+	tokens     map[string]struct{}
+	errorRules []*ErrorRule
+	lastID     int
+}
+
+type options struct {
+	clientID     string
+	clientSecret string
+	pageSize     int
+}
+
+type Option interface {
+	apply(opts *options)
+}
+
+var _ Option = optionFunc(nil)
+
+type optionFunc func(opts *options)
+
+func (fn optionFunc) apply(opts *options) {
+	fn(opts)
+}
+
+// WithCredentials makes the server reject token requests with other credentials. By default any non-empty
+// credentials are accepted.
+func WithCredentials(clientID, clientSecret string) Option {
+	return optionFunc(func(opts *options) {
+		opts.clientID = clientID
+		opts.clientSecret = clientSecret
+	})
+}
+
+func WithPageSize(pageSize int) Option {
+	return optionFunc(func(opts *options) {
+		opts.pageSize = pageSize
+	})
+}
+
+// NewServer starts a fake Oblio server with a single company, DefaultCIF, seeded with the default nomenclature.
+// The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	options := &options{
+		pageSize: DefaultPageSize,
+	}
+
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	s := &Server{
+		clientID:     options.clientID,
+		clientSecret: options.clientSecret,
+		pageSize:     options.pageSize,
+		tokens:       make(map[string]struct{}),
+	}
+
+	s.AddCompany(types.Company{
+		CIF:            DefaultCIF,
+		Company:        "OBLIOTEST SRL",
+		UserTypeAccess: "admin",
+	})
+
+	s.Server = httptest.NewServer(s.routes())
+
+	return s
+}
+
+// AddCompany registers a company seeded with VAT rates, one series per document type, languages and a
+// management.
+func (s *Server) AddCompany(info types.Company) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.companies = append(s.companies, &company{
+		info: info,
+		vatRates: []types.VATRate{
+			{Name: types.StandardVATName, Percent: types.NewDecimalFromInt(types.DefaultStandardVATRate), Default: true},
+			{Name: "Redusa", Percent: "9"},
+			{Name: "Redusa", Percent: "5"},
+			{Name: "SDD", Percent: "0"},
+		},
+		series: []types.Series{
+			{Type: seriesTypes[invoiceKind], Name: "FCT", Start: "0001", Next: "0001", Default: true},
+			{Type: seriesTypes[proformaKind], Name: "PRF", Start: "0001", Next: "0001", Default: true},
+			{Type: seriesTypes[noticeKind], Name: "AVZ", Start: "0001", Next: "0001", Default: true},
+		},
+		languages: []types.Language{
+			{Code: "RO", Name: "Romana"},
+			{Code: "EN", Name: "Engleza"},
+		},
+		management: []types.Management{
+			{Management: "Magazin", WorkStation: "Sediu", ManagementType: types.QuantitativeManagementType},
+		},
+	})
+}
+
+func (s *Server) AddClient(cif string, client types.Client) {
+	s.update(cif, func(c *company) {
+		c.clients = append(c.clients, client)
+	})
+}
+
+func (s *Server) AddProduct(cif string, product types.Product) {
+	s.update(cif, func(c *company) {
+		c.products = append(c.products, product)
+	})
+}
+
+// AddSeries registers a series or replaces the one with the same name.
+func (s *Server) AddSeries(cif string, series types.Series) {
+	s.update(cif, func(c *company) {
+		for i := range c.series {
+			if c.series[i].Name == series.Name {
+				c.series[i] = series
+
+				return
+			}
+		}
+
+		c.series = append(c.series, series)
+	})
+}
+
+func (s *Server) AddVATRate(cif string, rate types.VATRate) {
+	s.update(cif, func(c *company) {
+		c.vatRates = append(c.vatRates, rate)
+	})
+}
+
+func (s *Server) AddLanguage(cif string, language types.Language) {
+	s.update(cif, func(c *company) {
+		c.languages = append(c.languages, language)
+	})
+}
+
+func (s *Server) AddManagement(cif string, management types.Management) {
+	s.update(cif, func(c *company) {
+		c.management = append(c.management, management)
+	})
+}
+
+// Invoices returns a snapshot of the invoices issued for the given CIF.
+func (s *Server) Invoices(cif string) []types.Invoice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.company(cif)
+	if c == nil {
+		return nil
+	}
+
+	var out []types.Invoice
+
+	for _, doc := range c.documents {
+		if doc.kind == invoiceKind {
+			out = append(out, doc.invoice)
+		}
+	}
+
+	return out
+}
+
+// InjectError adds an error rule. Rules are checked in the order they were added.
+func (s *Server) InjectError(rule ErrorRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorRules = append(s.errorRules, &rule)
+}
+
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errorRules = nil
+}
+
+func (s *Server) update(cif string, fn func(c *company)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.company(cif)
+	if c == nil {
+		panic(fmt.Sprintf("obliotest: unknown company %q", cif))
+	}
+
+	fn(c)
+}
+
+func (s *Server) company(cif string) *company {
+	for _, c := range s.companies {
+		if c.info.CIF == cif {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) matchErrorRule(r *http.Request) *ErrorRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, rule := range s.errorRules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+
+		if rule.Path != "" && rule.Path != r.URL.Path {
+			continue
+		}
+
+		matched := *rule
+
+		if rule.Times > 0 {
+			rule.Times--
+
+			if rule.Times == 0 {
+				s.errorRules = append(s.errorRules[:i:i], s.errorRules[i+1:]...)
+			}
+		}
+
+		return &matched
+	}
+
+	return nil
+}
+
+func (s *Server) nextID() string {
+	s.lastID++
+
+	return strconv.Itoa(s.lastID)
+}
@@ -0,0 +1,219 @@
+package obliotest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func NewClient(t *testing.T, opts ...obliotest.Option) (*oblio.Client, *obliotest.Server) {
+	t.Helper()
+
+	srv := obliotest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	return oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL)), srv
+}
+
+func CreateInvoice(t *testing.T, client *oblio.Client) types.Document {
+	t.Helper()
+
+	resp, err := client.CreateInvoice(context.Background(), &oblio.CreateInvoiceRequest{
+		CIF:        obliotest.DefaultCIF,
+		SeriesName: "FCT",
+		IssueDate:  types.NewDate(2024, 1, 15),
+		Client: types.Client{
+			CIF:  "RO37311090",
+			Name: "OBLIO SOFTWARE SRL",
+		},
+		Products: []types.DocumentRow{
+			&types.LineItem{
+				Name:          "Montare",
+				Price:         "100",
+				VATPercentage: "19",
+				VATIncluded:   types.NewBool(false),
+				Quantity:      "2",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return resp.Data
+}
+
+func TestServer_Invoices(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("series numbering", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := NewClient(t)
+
+		require.Equal(t, "0001", CreateInvoice(t, client).Number)
+
+		doc := CreateInvoice(t, client)
+		require.Equal(t, "0002", doc.Number)
+		require.Equal(t, types.Decimal("238.00"), doc.Total)
+
+		series, err := client.GetSeries(ctx, &oblio.GetSeriesRequest{CIF: obliotest.DefaultCIF})
+		require.NoError(t, err)
+		require.Equal(t, "0003", series.Data[0].Next)
+	})
+
+	t.Run("cancel and restore", func(t *testing.T) {
+		t.Parallel()
+
+		client, srv := NewClient(t)
+		doc := CreateInvoice(t, client)
+		req := &oblio.DocumentRequest{CIF: obliotest.DefaultCIF, SeriesName: doc.SeriesName, Number: doc.Number}
+
+		_, err := client.RestoreInvoice(ctx, req)
+		require.Error(t, err)
+
+		_, err = client.CancelInvoice(ctx, req)
+		require.NoError(t, err)
+		require.True(t, bool(srv.Invoices(obliotest.DefaultCIF)[0].Canceled))
+
+		_, err = client.CancelInvoice(ctx, req)
+		require.Error(t, err)
+
+		_, err = client.RestoreInvoice(ctx, req)
+		require.NoError(t, err)
+		require.False(t, bool(srv.Invoices(obliotest.DefaultCIF)[0].Canceled))
+	})
+
+	t.Run("delete only the last number", func(t *testing.T) {
+		t.Parallel()
+
+		client, srv := NewClient(t)
+		first := CreateInvoice(t, client)
+		last := CreateInvoice(t, client)
+
+		_, err := client.DeleteInvoice(ctx, &oblio.DocumentRequest{
+			CIF: obliotest.DefaultCIF, SeriesName: first.SeriesName, Number: first.Number,
+		})
+		require.Error(t, err)
+
+		_, err = client.DeleteInvoice(ctx, &oblio.DocumentRequest{
+			CIF: obliotest.DefaultCIF, SeriesName: last.SeriesName, Number: last.Number,
+		})
+		require.NoError(t, err)
+		require.Len(t, srv.Invoices(obliotest.DefaultCIF), 1)
+
+		require.Equal(t, last.Number, CreateInvoice(t, client).Number)
+	})
+
+	t.Run("collect", func(t *testing.T) {
+		t.Parallel()
+
+		client, srv := NewClient(t)
+		doc := CreateInvoice(t, client)
+
+		resp, err := client.Collect(ctx, &oblio.CollectRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: doc.SeriesName,
+			Number:     doc.Number,
+			Collects: []types.Collect{
+				{Type: types.CardCollectType, Value: "238.00"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Data.Collects, 1)
+		require.True(t, bool(srv.Invoices(obliotest.DefaultCIF)[0].Collected))
+	})
+
+	t.Run("list with pagination", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := NewClient(t)
+
+		for range 5 {
+			CreateInvoice(t, client)
+		}
+
+		resp, err := client.GetInvoices(ctx, &oblio.GetInvoicesRequest{
+			CIF:          obliotest.DefaultCIF,
+			LimitPerPage: 2,
+			Offset:       4,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Data, 1)
+		require.Equal(t, "0005", resp.Data[0].Number)
+
+		resp, err = client.GetInvoices(ctx, &oblio.GetInvoicesRequest{
+			CIF:         obliotest.DefaultCIF,
+			IssuedAfter: types.NewDate(2024, 2, 1),
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Data)
+	})
+
+	t.Run("unknown series", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := NewClient(t)
+
+		_, err := client.CreateInvoice(ctx, &oblio.CreateInvoiceRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: "PRF",
+			Client:     types.Client{Name: "Ion Popescu"},
+			Products:   []types.DocumentRow{&types.LineItem{Name: "Montare", Price: "100"}},
+		})
+
+		var errResp *oblio.ErrorResponse
+
+		require.True(t, errors.As(err, &errResp))
+		require.Equal(t, http.StatusBadRequest, errResp.Status)
+		require.Equal(t, "Seria PRF nu exista", errResp.Message)
+	})
+}
+
+func TestServer_InjectError(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		client, srv = NewClient(t)
+		req         = &oblio.GetVATRatesRequest{CIF: obliotest.DefaultCIF}
+	)
+
+	srv.InjectError(obliotest.ErrorRule{
+		Path:    "/nomenclature/vat_rates",
+		Status:  http.StatusServiceUnavailable,
+		Message: "maintenance",
+		Times:   1,
+	})
+
+	_, err := client.GetVATRates(ctx, req)
+
+	var errResp *oblio.ErrorResponse
+
+	require.ErrorAs(t, err, &errResp)
+	require.Equal(t, http.StatusServiceUnavailable, errResp.Status)
+	require.Equal(t, "maintenance", errResp.Message)
+
+	resp, err := client.GetVATRates(ctx, req)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Data)
+	require.True(t, resp.Data[0].Percent.Equal(types.NewDecimalFromInt(types.DefaultStandardVATRate)))
+}
+
+func TestServer_WithCredentials(t *testing.T) {
+	t.Parallel()
+
+	srv := obliotest.NewServer(obliotest.WithCredentials("id", "secret"))
+	t.Cleanup(srv.Close)
+
+	client := oblio.NewClient("id", "wrong", oblio.WithBaseURL(srv.URL))
+
+	_, err := client.GetCompanies(context.Background(), &oblio.GetCompaniesRequest{})
+	require.Error(t, err)
+}
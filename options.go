@@ -9,6 +9,12 @@ type options struct {
 	client       *http.Client
 	baseURL      string
 	tokenStorage TokenStorage
+	cacheStorage CacheStorage
+	cacheTTLs    map[NomenclatureEndpoint]CacheTTL
+	cacheErrors  func(err error)
+	preflight    bool
+	rates        ExchangeRateProvider
+	strictEnums  bool
 }
 
 type Option interface {
@@ -41,11 +47,56 @@ func WithTokenStorage(tokenStorage TokenStorage) Option {
 	})
 }
 
+func WithCache(storage CacheStorage) Option {
+	return optionFunc(func(opts *options) {
+		opts.cacheStorage = storage
+	})
+}
+
+func WithCacheTTL(endpoint NomenclatureEndpoint, ttl CacheTTL) Option {
+	return optionFunc(func(opts *options) {
+		opts.cacheTTLs[endpoint] = ttl
+	})
+}
+
+// WithCacheErrorHandler receives the nomenclature cache errors that do not fail the call, such as a response
+// that could not be cached or a background refresh that failed. They are dropped by default.
+func WithCacheErrorHandler(fn func(err error)) Option {
+	return optionFunc(func(opts *options) {
+		opts.cacheErrors = fn
+	})
+}
+
+// WithPreflight runs Preflight before every invoice, proforma and notice create call, so requests that do not
+// match the company nomenclature fail without being sent.
+func WithPreflight() Option {
+	return optionFunc(func(opts *options) {
+		opts.preflight = true
+	})
+}
+
+// WithExchangeRates fills the exchange rate of foreign-currency documents created without one with the rate of
+// their issue date.
+func WithExchangeRates(provider ExchangeRateProvider) Option {
+	return optionFunc(func(opts *options) {
+		opts.rates = provider
+	})
+}
+
+// WithStrictEnums rejects with ErrInvalidArgument, before sending them, requests holding enum values Oblio does
+// not know, e.g. a typo such as types.CollectType("Ordin plata"). Empty values are always allowed.
+func WithStrictEnums() Option {
+	return optionFunc(func(opts *options) {
+		opts.strictEnums = true
+	})
+}
+
 func newOptions(opts []Option) *options {
 	options := &options{
 		baseURL:      BaseURL,
 		client:       http.DefaultClient,
 		tokenStorage: token.NewInMemStorage(),
+		cacheTTLs:    DefaultCacheTTLs(),
 	}
 
 	for _, opt := range opts {
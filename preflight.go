@@ -0,0 +1,199 @@
+package oblio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+var _ CreateDocumentRequest = (*CreateInvoiceRequest)(nil)
+
+// CreateDocumentRequest is implemented by the invoice, proforma and notice create requests.
+type CreateDocumentRequest interface {
+	Validator
+
+	documentFields() documentFields
+	setExchangeRate(rate types.Decimal)
+	setDueDate(date types.Date)
+}
+
+// Preflight cross-checks a create request against the nomenclature of its company and reports every mismatch
+// wrapped in ErrNomenclatureMismatch: a series that does not exist for the document type, an unknown language,
+// VAT name or management. The nomenclature is fetched through the cache when one is configured. Enable
+// WithPreflight to run it before every create call.
+func (c *Client) Preflight(ctx context.Context, req CreateDocumentRequest) error {
+	doc := req.documentFields()
+
+	var errs []error
+
+	series, err := c.GetSeries(ctx, &GetSeriesRequest{CIF: doc.cif})
+	if err != nil {
+		return fmt.Errorf("getSeries: %w", err)
+	}
+
+	if !hasSeries(series.Data, doc.seriesType, doc.seriesName) {
+		errs = append(errs, fmt.Errorf(
+			"seriesName %q is not a %s series: %w", doc.seriesName, doc.seriesType, ErrNomenclatureMismatch))
+	}
+
+	if doc.language != "" {
+		languages, err := c.GetLanguages(ctx, &GetLanguagesRequest{CIF: doc.cif})
+		if err != nil {
+			return fmt.Errorf("getLanguages: %w", err)
+		}
+
+		if !hasLanguage(languages.Data, doc.language) {
+			errs = append(errs, fmt.Errorf("language %q is unknown: %w", doc.language, ErrNomenclatureMismatch))
+		}
+	}
+
+	vatErrs, err := c.preflightVATRates(ctx, doc)
+	if err != nil {
+		return err
+	}
+
+	errs = append(errs, vatErrs...)
+
+	managementErrs, err := c.preflightManagement(ctx, doc)
+	if err != nil {
+		return err
+	}
+
+	errs = append(errs, managementErrs...)
+
+	return errors.Join(errs...)
+}
+
+func (c *Client) preflight(ctx context.Context, req CreateDocumentRequest) error {
+	if !c.preflightEnabled {
+		return nil
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Preflight(ctx, req); err != nil {
+		return fmt.Errorf("preflight: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) preflightVATRates(ctx context.Context, doc documentFields) ([]error, error) {
+	items := lineItems(doc.products, func(item *types.LineItem) bool {
+		return item.VATName != ""
+	})
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	rates, err := c.GetVATRates(ctx, &GetVATRatesRequest{CIF: doc.cif})
+	if err != nil {
+		return nil, fmt.Errorf("getVATRates: %w", err)
+	}
+
+	var errs []error
+
+	for _, item := range items {
+		if !hasVATRate(rates.Data, item.VATName, item.VATPercentage) {
+			errs = append(errs, fmt.Errorf("products[%d].vatName %q with vatPercentage %s is unknown: %w",
+				item.row, item.VATName, item.VATPercentage, ErrNomenclatureMismatch))
+		}
+	}
+
+	return errs, nil
+}
+
+func (c *Client) preflightManagement(ctx context.Context, doc documentFields) ([]error, error) {
+	items := lineItems(doc.products, func(item *types.LineItem) bool {
+		return item.Management != ""
+	})
+	if len(items) == 0 && doc.workStation == "" {
+		return nil, nil
+	}
+
+	management, err := c.GetManagement(ctx, &GetManagementRequest{CIF: doc.cif})
+	if err != nil {
+		return nil, fmt.Errorf("getManagement: %w", err)
+	}
+
+	var errs []error
+
+	if doc.workStation != "" && !hasManagement(management.Data, "", doc.workStation) {
+		errs = append(errs, fmt.Errorf("workStation %q is unknown: %w", doc.workStation, ErrNomenclatureMismatch))
+	}
+
+	for _, item := range items {
+		if !hasManagement(management.Data, item.Management, doc.workStation) {
+			errs = append(errs, fmt.Errorf(
+				"products[%d].management %q is unknown: %w", item.row, item.Management, ErrNomenclatureMismatch))
+		}
+	}
+
+	return errs, nil
+}
+
+type rowItem struct {
+	*types.LineItem
+
+	row int
+}
+
+// lineItems returns the line items accepted by fn along with their position in rows.
+func lineItems(rows []types.DocumentRow, fn func(item *types.LineItem) bool) []rowItem {
+	var items []rowItem
+
+	for i, row := range rows {
+		if item, ok := row.(*types.LineItem); ok && fn(item) {
+			items = append(items, rowItem{LineItem: item, row: i})
+		}
+	}
+
+	return items
+}
+
+func hasSeries(series []types.Series, seriesType types.DocumentType, name string) bool {
+	for _, s := range series {
+		if s.Type == seriesType && s.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasLanguage(languages []types.Language, code string) bool {
+	for _, language := range languages {
+		if strings.EqualFold(language.Code, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasVATRate reports whether a VAT rate has the given name and, when it is not zero, percentage.
+func hasVATRate(rates []types.VATRate, name string, percent types.Decimal) bool {
+	for _, rate := range rates {
+		if rate.Name == name && (percent.IsZero() || rate.Percent.Equal(percent)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasManagement reports whether a management matches the given name and work station, empty values match any.
+func hasManagement(management []types.Management, name, workStation string) bool {
+	for _, m := range management {
+		if (name == "" || m.Management == name) && (workStation == "" || m.WorkStation == workStation) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package oblio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestClient_Preflight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newClient := func(t *testing.T, opts ...oblio.Option) (*oblio.Client, *obliotest.Server) {
+		t.Helper()
+
+		srv := obliotest.NewServer()
+		t.Cleanup(srv.Close)
+
+		return oblio.NewClient("client-id", "client-secret", append(opts, oblio.WithBaseURL(srv.URL))...), srv
+	}
+
+	newRequest := func() *oblio.CreateInvoiceRequest {
+		return &oblio.CreateInvoiceRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: "FCT",
+			Language:   "EN",
+			Client:     types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Redusa", VATPercentage: "5", Management: "Magazin"},
+			},
+		}
+	}
+
+	t.Run("matching nomenclature", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newClient(t)
+
+		require.NoError(t, client.Preflight(ctx, newRequest()))
+	})
+
+	t.Run("every mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		client, _ := newClient(t)
+		req := newRequest()
+		req.SeriesName = "PRF"
+		req.Language = "DE"
+		req.WorkStation = "Depozit"
+		req.Products = append(req.Products,
+			&types.LineItem{Name: "Caiet", Price: "10", VATName: "Redusa", VATPercentage: "11", Management: "Depozit"})
+
+		err := client.Preflight(ctx, req)
+		require.ErrorIs(t, err, oblio.ErrNomenclatureMismatch)
+
+		for _, want := range []string{
+			`seriesName "PRF" is not a Factura series`,
+			`language "DE"`,
+			`workStation "Depozit"`,
+			`products[1].vatName "Redusa" with vatPercentage 11`,
+			`products[0].management "Magazin"`,
+			`products[1].management "Depozit"`,
+		} {
+			require.ErrorContains(t, err, want)
+		}
+	})
+
+	t.Run("create with preflight", func(t *testing.T) {
+		t.Parallel()
+
+		client, srv := newClient(t, oblio.WithPreflight())
+		req := newRequest()
+		req.SeriesName = "AVZ"
+
+		_, err := client.CreateInvoice(ctx, req)
+		require.ErrorIs(t, err, oblio.ErrNomenclatureMismatch)
+		require.Empty(t, srv.Invoices(obliotest.DefaultCIF))
+
+		_, err = client.CreateInvoice(ctx, newRequest())
+		require.NoError(t, err)
+		require.Len(t, srv.Invoices(obliotest.DefaultCIF), 1)
+	})
+}
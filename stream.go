@@ -0,0 +1,152 @@
+package oblio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+// streamDecoder is implemented by responses decoded while they are read instead of all at once.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+var _ streamDecoder = (*streamResponse[types.Invoice])(nil)
+
+// StreamResponse is the status of a streamed list, its items having been handed to the callback.
+type StreamResponse struct {
+	Status
+
+	// Count is the number of items handed to the callback, the offset of the next page being Offset + Count.
+	Count int
+}
+
+type streamResponse[T any] struct {
+	StreamResponse
+
+	fn func(item T) error
+}
+
+// decodeStream walks the response object and decodes the items of its data array one at a time.
+func (r *streamResponse[T]) decodeStream(dec *json.Decoder) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case "status":
+			err = dec.Decode(&r.Status.Status)
+		case "statusMessage":
+			err = dec.Decode(&r.StatusMessage)
+		case "data":
+			err = r.decodeData(dec)
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+
+		if err != nil {
+			return fmt.Errorf("%v: %w", token, err)
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (r *streamResponse[T]) decodeData(dec *json.Decoder) error {
+	if !dec.More() {
+		return nil
+	}
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return nil
+	}
+
+	if token != json.Delim('[') {
+		return fmt.Errorf("unexpected %v, want an array", token)
+	}
+
+	for dec.More() {
+		var item T
+
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("[%d]: %w", r.Count, err)
+		}
+
+		if err := r.fn(item); err != nil {
+			return err
+		}
+
+		r.Count++
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != want {
+		return fmt.Errorf("unexpected %v, want %v", token, want)
+	}
+
+	return nil
+}
+
+// StreamInvoices lists a page of invoices like GetInvoices, handing each invoice to fn as soon as it is decoded
+// instead of holding the whole page in memory. An error returned by fn stops the listing and is returned.
+func (c *Client) StreamInvoices(
+	ctx context.Context, req *GetInvoicesRequest, fn func(invoice types.Invoice) error,
+) (*StreamResponse, error) {
+	resp := &streamResponse[types.Invoice]{fn: fn}
+
+	if err := c.callDocsAPI(ctx, http.MethodGet, "/invoice/list", req, resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.StreamResponse, nil
+}
+
+// StreamClients lists a page of clients like GetClients, handing each client to fn as soon as it is decoded.
+// The response is never served from the nomenclature cache.
+func (c *Client) StreamClients(
+	ctx context.Context, req *GetClientsRequest, fn func(client types.Client) error,
+) (*StreamResponse, error) {
+	resp := &streamResponse[types.Client]{fn: fn}
+
+	if err := c.callAPI(ctx, http.MethodGet, "/nomenclature", string(ClientsEndpoint), req, resp); err != nil {
+		return nil, fmt.Errorf("callAPI: %w", err)
+	}
+
+	return &resp.StreamResponse, nil
+}
+
+// StreamProducts lists a page of products like GetProducts, handing each product to fn as soon as it is
+// decoded. The response is never served from the nomenclature cache.
+func (c *Client) StreamProducts(
+	ctx context.Context, req *GetProductsRequest, fn func(product types.Product) error,
+) (*StreamResponse, error) {
+	resp := &streamResponse[types.Product]{fn: fn}
+
+	if err := c.callAPI(ctx, http.MethodGet, "/nomenclature", string(ProductsEndpoint), req, resp); err != nil {
+		return nil, fmt.Errorf("callAPI: %w", err)
+	}
+
+	return &resp.StreamResponse, nil
+}
@@ -0,0 +1,264 @@
+package oblio_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestClient_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := obliotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+
+	for i := range 3 {
+		srv.AddClient(obliotest.DefaultCIF, types.Client{Name: fmt.Sprintf("Client %d", i)})
+		srv.AddProduct(obliotest.DefaultCIF, types.Product{Name: fmt.Sprintf("Produs %d", i)})
+
+		_, err := client.CreateInvoice(ctx, &oblio.CreateInvoiceRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: "FCT",
+			Client:     types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("clients", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+
+		resp, err := client.StreamClients(ctx, &oblio.GetClientsRequest{CIF: obliotest.DefaultCIF},
+			func(client types.Client) error {
+				names = append(names, client.Name)
+
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.Status.Status)
+		require.Equal(t, 3, resp.Count)
+		require.Equal(t, []string{"Client 0", "Client 1", "Client 2"}, names)
+	})
+
+	t.Run("products", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+
+		resp, err := client.StreamProducts(ctx, &oblio.GetProductsRequest{CIF: obliotest.DefaultCIF, Offset: 1},
+			func(product types.Product) error {
+				names = append(names, product.Name)
+
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, 2, resp.Count)
+		require.Equal(t, []string{"Produs 1", "Produs 2"}, names)
+	})
+
+	t.Run("invoices", func(t *testing.T) {
+		t.Parallel()
+
+		want, err := client.GetInvoices(ctx, &oblio.GetInvoicesRequest{CIF: obliotest.DefaultCIF})
+		require.NoError(t, err)
+
+		var got []types.Invoice
+
+		resp, err := client.StreamInvoices(ctx, &oblio.GetInvoicesRequest{CIF: obliotest.DefaultCIF},
+			func(invoice types.Invoice) error {
+				got = append(got, invoice)
+
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, 3, resp.Count)
+		require.Equal(t, want.Data, got)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			wantErr = errors.New("boom")
+			calls   int
+		)
+
+		_, err := client.StreamProducts(ctx, &oblio.GetProductsRequest{CIF: obliotest.DefaultCIF},
+			func(types.Product) error {
+				calls++
+
+				return wantErr
+			})
+		require.ErrorIs(t, err, wantErr)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestClient_StreamProducts_Decode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		body      string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "unknown keys around data",
+			body:      `{"status":200,"extra":{"a":[1,2]},"data":[{"name":"A"},{"name":"B"}],"statusMessage":"Success"}`,
+			wantNames: []string{"A", "B"},
+		},
+		{
+			name: "null data",
+			body: `{"status":200,"statusMessage":"Success","data":null}`,
+		},
+		{
+			name:    "data is not an array",
+			body:    `{"status":200,"data":{"name":"A"}}`,
+			wantErr: true,
+		},
+		{
+			name:      "malformed item",
+			body:      `{"status":200,"data":[{"name":"A"},{"name":1}]}`,
+			wantNames: []string{"A"},
+			wantErr:   true,
+		},
+		{
+			name: "truncated body",
+			body: `{"status":200,"data":[{"name":"A"}`,
+			// The item is complete, so it is handed to the callback before the body ends.
+			wantNames: []string{"A"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(srv.Close)
+
+			client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+
+			var names []string
+
+			resp, err := client.StreamProducts(context.Background(), &oblio.GetProductsRequest{
+				Authorized: oblio.Authorized{AccessToken: "access-token"},
+				CIF:        obliotest.DefaultCIF,
+			}, func(product types.Product) error {
+				names = append(names, product.Name)
+
+				return nil
+			})
+			require.Equal(t, tt.wantNames, names)
+
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "Success", resp.StatusMessage)
+			require.Equal(t, len(tt.wantNames), resp.Count)
+		})
+	}
+}
+
+// newProductsServer serves a page of n products, each stocked in a few management units.
+func newProductsServer(b *testing.B, n int) *httptest.Server {
+	b.Helper()
+
+	products := make([]types.Product, n)
+
+	for i := range products {
+		products[i] = types.Product{
+			Name:          fmt.Sprintf("Produs %d", i),
+			Code:          fmt.Sprintf("P%06d", i),
+			Description:   "Lorem ipsum dolor sit amet, consectetur adipiscing elit",
+			MeasuringUnit: "buc",
+			Price:         "119.99",
+			Currency:      "RON",
+			VATName:       "Normala",
+			VATPercentage: "19",
+		}
+
+		for j := range 5 {
+			products[i].Stock = append(products[i].Stock, types.Stock{
+				WorkStation: "Sediu",
+				Management:  fmt.Sprintf("Gestiune %d", j),
+				Quantity:    "10",
+				Price:       "100.83",
+				Currency:    "RON",
+			})
+		}
+	}
+
+	body, err := json.Marshal(oblio.GetProductsResponse{
+		Status: oblio.Status{Status: http.StatusOK, StatusMessage: "Success"},
+		Data:   products,
+	})
+	require.NoError(b, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	b.Cleanup(srv.Close)
+
+	return srv
+}
+
+var benchmarkProductsRequest = &oblio.GetProductsRequest{
+	Authorized: oblio.Authorized{AccessToken: "access-token"},
+	CIF:        obliotest.DefaultCIF,
+}
+
+func BenchmarkClient_GetProducts(b *testing.B) {
+	srv := newProductsServer(b, 5000)
+	client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for range b.N {
+		resp, err := client.GetProducts(ctx, benchmarkProductsRequest)
+		require.NoError(b, err)
+		require.Len(b, resp.Data, 5000)
+	}
+}
+
+func BenchmarkClient_StreamProducts(b *testing.B) {
+	srv := newProductsServer(b, 5000)
+	client := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for range b.N {
+		resp, err := client.StreamProducts(ctx, benchmarkProductsRequest, func(types.Product) error {
+			return nil
+		})
+		require.NoError(b, err)
+		require.Equal(b, 5000, resp.Count)
+	}
+}
@@ -0,0 +1,281 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const BucharestCountyCode = "RO-B"
+
+type County struct {
+	// Code is the ISO 3166-2:RO code, e.g. "RO-CJ".
+	Code string
+	Name string
+}
+
+type Country struct {
+	// Code is the ISO 3166-1 alpha-2 code, e.g. "RO".
+	Code string
+	Name string
+}
+
+var counties = []County{
+	{Code: "RO-AB", Name: "Alba"},
+	{Code: "RO-AR", Name: "Arad"},
+	{Code: "RO-AG", Name: "Argeș"},
+	{Code: "RO-BC", Name: "Bacău"},
+	{Code: "RO-BH", Name: "Bihor"},
+	{Code: "RO-BN", Name: "Bistrița-Năsăud"},
+	{Code: "RO-BT", Name: "Botoșani"},
+	{Code: "RO-BV", Name: "Brașov"},
+	{Code: "RO-BR", Name: "Brăila"},
+	{Code: BucharestCountyCode, Name: "București"},
+	{Code: "RO-BZ", Name: "Buzău"},
+	{Code: "RO-CS", Name: "Caraș-Severin"},
+	{Code: "RO-CL", Name: "Călărași"},
+	{Code: "RO-CJ", Name: "Cluj"},
+	{Code: "RO-CT", Name: "Constanța"},
+	{Code: "RO-CV", Name: "Covasna"},
+	{Code: "RO-DB", Name: "Dâmbovița"},
+	{Code: "RO-DJ", Name: "Dolj"},
+	{Code: "RO-GL", Name: "Galați"},
+	{Code: "RO-GR", Name: "Giurgiu"},
+	{Code: "RO-GJ", Name: "Gorj"},
+	{Code: "RO-HR", Name: "Harghita"},
+	{Code: "RO-HD", Name: "Hunedoara"},
+	{Code: "RO-IL", Name: "Ialomița"},
+	{Code: "RO-IS", Name: "Iași"},
+	{Code: "RO-IF", Name: "Ilfov"},
+	{Code: "RO-MM", Name: "Maramureș"},
+	{Code: "RO-MH", Name: "Mehedinți"},
+	{Code: "RO-MS", Name: "Mureș"},
+	{Code: "RO-NT", Name: "Neamț"},
+	{Code: "RO-OT", Name: "Olt"},
+	{Code: "RO-PH", Name: "Prahova"},
+	{Code: "RO-SM", Name: "Satu Mare"},
+	{Code: "RO-SJ", Name: "Sălaj"},
+	{Code: "RO-SB", Name: "Sibiu"},
+	{Code: "RO-SV", Name: "Suceava"},
+	{Code: "RO-TR", Name: "Teleorman"},
+	{Code: "RO-TM", Name: "Timiș"},
+	{Code: "RO-TL", Name: "Tulcea"},
+	{Code: "RO-VS", Name: "Vaslui"},
+	{Code: "RO-VL", Name: "Vâlcea"},
+	{Code: "RO-VN", Name: "Vrancea"},
+}
+
+// countryAliases maps the ISO codes of the usual trading partners to their Romanian and other usual names, the
+// first one being the canonical name. The other countries are named as in isoCountries.
+var countryAliases = map[string][]string{
+	"AT": {"Austria"},
+	"BE": {"Belgia", "Belgium"},
+	"BG": {"Bulgaria"},
+	"CA": {"Canada"},
+	"CH": {"Elveția", "Switzerland"},
+	"CN": {"China"},
+	"CY": {"Cipru", "Cyprus"},
+	"CZ": {"Cehia", "Republica Cehă", "Czechia", "Czech Republic"},
+	"DE": {"Germania", "Germany"},
+	"DK": {"Danemarca", "Denmark"},
+	"EE": {"Estonia"},
+	"ES": {"Spania", "Spain"},
+	"FI": {"Finlanda", "Finland"},
+	"FR": {"Franța", "France"},
+	"GB": {"Marea Britanie", "Regatul Unit", "United Kingdom", "Great Britain", "UK"},
+	"GR": {"Grecia", "Greece"},
+	"HR": {"Croația", "Croatia"},
+	"HU": {"Ungaria", "Hungary"},
+	"IE": {"Irlanda", "Ireland"},
+	"IT": {"Italia", "Italy"},
+	"LT": {"Lituania", "Lithuania"},
+	"LU": {"Luxemburg", "Luxembourg"},
+	"LV": {"Letonia", "Latvia"},
+	"MD": {"Republica Moldova", "Moldova"},
+	"MT": {"Malta"},
+	"NL": {"Olanda", "Țările de Jos", "Netherlands", "Holland"},
+	"NO": {"Norvegia", "Norway"},
+	"PL": {"Polonia", "Poland"},
+	"PT": {"Portugalia", "Portugal"},
+	"RO": {"România", "Romania"},
+	"RS": {"Serbia"},
+	"SE": {"Suedia", "Sweden"},
+	"SI": {"Slovenia"},
+	"SK": {"Slovacia", "Slovakia"},
+	"TR": {"Turcia", "Turkey", "Türkiye"},
+	"UA": {"Ucraina", "Ukraine"},
+	"US": {"Statele Unite", "SUA", "United States", "USA"},
+}
+
+var (
+	countyIndex  = indexCounties()
+	countryIndex = indexCountries()
+	sectorRegexp = regexp.MustCompile(`\b(?:SECTORUL|SECTOR|SECT|S)\s*([1-6])\b`)
+	addressTrim  = regexp.MustCompile(`^(?:JUDETUL|JUDET|JUD|MUNICIPIUL|MUN)\s+`)
+	diacritics   = strings.NewReplacer(
+		"ă", "a", "â", "a", "î", "i", "ș", "s", "ş", "s", "ț", "t", "ţ", "t",
+		"Ă", "A", "Â", "A", "Î", "I", "Ș", "S", "Ş", "S", "Ț", "T", "Ţ", "T",
+	)
+)
+
+// LookupCounty resolves a county from its name, abbreviation or ISO code, with or without diacritics and
+// prefixes such as "Jud.", e.g. "CLUJ", "jud. Cluj", "CJ" and "RO-CJ". Bucharest sectors resolve to Bucharest.
+func LookupCounty(s string) (County, bool) {
+	key := normalizeAddressPart(s)
+
+	if county, ok := countyIndex[key]; ok {
+		return county, true
+	}
+
+	if _, ok := LookupSector(s); ok {
+		return countyIndex["B"], true
+	}
+
+	return County{}, false
+}
+
+// LookupSector finds a Bucharest sector such as "Sector 3", "Sectorul 3" or "S3" in s.
+func LookupSector(s string) (int, bool) {
+	match := sectorRegexp.FindStringSubmatch(normalizeAddressPart(s))
+	if match == nil {
+		return 0, false
+	}
+
+	return int(match[1][0] - '0'), true
+}
+
+// LookupCountry resolves a country from its English name, its Romanian name for the usual trading partners or
+// its ISO 3166-1 alpha-2 code, Greece also from its VAT prefix EL.
+func LookupCountry(s string) (Country, bool) {
+	country, ok := countryIndex[normalizeAddressPart(s)]
+
+	return country, ok
+}
+
+// NormalizedAddress is the address of a client resolved to canonical names and ISO codes.
+type NormalizedAddress struct {
+	County  County
+	Sector  int
+	Country Country
+}
+
+// NormalizeAddress rewrites State with the ISO 3166-2 code of the county e-Factura expects, e.g. "RO-CJ",
+// Country with its canonical name and, for Bucharest, City with the sector, e.g. "Sector 3". Romanian counties are only resolved for Romanian clients, an empty Country meaning
+// Romania. Every part that cannot be resolved is reported and left unchanged.
+func (c *Client) NormalizeAddress() (NormalizedAddress, error) {
+	var (
+		out  NormalizedAddress
+		errs []error
+	)
+
+	country := Country{Code: "RO", Name: "România"}
+
+	if c.Country != "" {
+		var ok bool
+
+		if country, ok = LookupCountry(c.Country); !ok {
+			errs = append(errs, fmt.Errorf("country %q is unknown: %w", c.Country, ErrInvalidArgument))
+		}
+	}
+
+	out.Country = country
+
+	if country.Code != "RO" {
+		if country.Code != "" {
+			c.Country = country.Name
+		}
+
+		return out, errors.Join(errs...)
+	}
+
+	if c.Country != "" {
+		c.Country = country.Name
+	}
+
+	state := c.State
+
+	county, ok := LookupCounty(state)
+	if !ok && state == "" {
+		// The county is often left out for Bucharest, whose sector is written in the city.
+		if _, isSector := LookupSector(c.City); isSector {
+			county, ok = countyIndex["B"], true
+		}
+	}
+
+	if !ok {
+		return out, errors.Join(append(errs,
+			fmt.Errorf("state %q is not a Romanian county: %w", state, ErrInvalidArgument))...)
+	}
+
+	out.County = county
+	c.State = county.Code
+
+	if county.Code != BucharestCountyCode {
+		return out, errors.Join(errs...)
+	}
+
+	sector, ok := LookupSector(c.City)
+	if !ok {
+		sector, ok = LookupSector(state)
+	}
+
+	if !ok {
+		return out, errors.Join(append(errs,
+			fmt.Errorf("city %q is not a Bucharest sector: %w", c.City, ErrInvalidArgument))...)
+	}
+
+	out.Sector = sector
+	c.City = fmt.Sprintf("Sector %d", sector)
+
+	return out, errors.Join(errs...)
+}
+
+func normalizeAddressPart(s string) string {
+	s = strings.ToUpper(diacritics.Replace(s))
+	s = strings.NewReplacer("-", " ", ".", " ", ",", " ").Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+
+	return addressTrim.ReplaceAllString(s, "")
+}
+
+func indexCounties() map[string]County {
+	index := make(map[string]County, len(counties)*3)
+
+	for _, county := range counties {
+		code := strings.TrimPrefix(county.Code, "RO-")
+
+		index[normalizeAddressPart(county.Name)] = county
+		index[normalizeAddressPart(county.Code)] = county
+		index[code] = county
+	}
+
+	index["BUCHAREST"] = index["B"]
+	index["BUC"] = index["B"]
+
+	return index
+}
+
+func indexCountries() map[string]Country {
+	index := make(map[string]Country, len(isoCountries)*2)
+
+	for code, names := range isoCountries {
+		country := Country{Code: code, Name: names[0]}
+
+		if aliases := countryAliases[code]; len(aliases) > 0 {
+			country.Name = aliases[0]
+		}
+
+		index[code] = country
+
+		for _, name := range slices.Concat(names, countryAliases[code]) {
+			index[normalizeAddressPart(name)] = country
+		}
+	}
+
+	// EL is the VAT prefix of Greece.
+	index["EL"] = index["GR"]
+
+	return index
+}
@@ -0,0 +1,147 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestLookupCounty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "CLUJ", want: "RO-CJ"},
+		{in: "jud. Cluj", want: "RO-CJ"},
+		{in: "Judetul Bistrita Nasaud", want: "RO-BN"},
+		{in: "Bistrița-Năsăud", want: "RO-BN"},
+		{in: "BN", want: "RO-BN"},
+		{in: "RO-IS", want: "RO-IS"},
+		{in: "IAŞI", want: "RO-IS"},
+		{in: "Mun. București", want: types.BucharestCountyCode},
+		{in: "Sectorul 4", want: types.BucharestCountyCode},
+		{in: "Satu-Mare", want: "RO-SM"},
+		{in: "Atlantis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := types.LookupCounty(tt.in)
+			require.Equal(t, tt.want != "", ok)
+			require.Equal(t, tt.want, got.Code)
+		})
+	}
+}
+
+func TestLookupCountry(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"ROMANIA":        "RO",
+		"România":        "RO",
+		"de":             "DE",
+		"Germania":       "DE",
+		"Țările de Jos":  "NL",
+		"united kingdom": "GB",
+		"JP":             "JP",
+		"Australia":      "AU",
+		"EL":             "GR",
+		"Bolivia":        "BO",
+		"Narnia":         "",
+	} {
+		got, ok := types.LookupCountry(in)
+		require.Equal(t, want != "", ok, in)
+		require.Equal(t, want, got.Code, in)
+	}
+}
+
+func TestClient_NormalizeAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		client     types.Client
+		want       types.NormalizedAddress
+		wantClient types.Client
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "county",
+			client: types.Client{State: "CLUJ", City: "MUN. CLUJ-NAPOCA", Country: "ROMANIA"},
+			want: types.NormalizedAddress{
+				County:  types.County{Code: "RO-CJ", Name: "Cluj"},
+				Country: types.Country{Code: "RO", Name: "România"},
+			},
+			wantClient: types.Client{State: "RO-CJ", City: "MUN. CLUJ-NAPOCA", Country: "România"},
+		},
+		{
+			name:   "bucharest sector in city",
+			client: types.Client{City: "Bucuresti, sectorul 2"},
+			want: types.NormalizedAddress{
+				County:  types.County{Code: types.BucharestCountyCode, Name: "București"},
+				Sector:  2,
+				Country: types.Country{Code: "RO", Name: "România"},
+			},
+			wantClient: types.Client{State: types.BucharestCountyCode, City: "Sector 2"},
+		},
+		{
+			name:   "bucharest sector in state",
+			client: types.Client{State: "Sector 6", City: "Bucuresti"},
+			want: types.NormalizedAddress{
+				County:  types.County{Code: types.BucharestCountyCode, Name: "București"},
+				Sector:  6,
+				Country: types.Country{Code: "RO", Name: "România"},
+			},
+			wantClient: types.Client{State: types.BucharestCountyCode, City: "Sector 6"},
+		},
+		{
+			name:       "foreign",
+			client:     types.Client{State: "Bayern", City: "München", Country: "germany"},
+			want:       types.NormalizedAddress{Country: types.Country{Code: "DE", Name: "Germania"}},
+			wantClient: types.Client{State: "Bayern", City: "München", Country: "Germania"},
+		},
+		{
+			name:   "unresolved",
+			client: types.Client{State: "Bucuresti", City: "Bucuresti"},
+			want: types.NormalizedAddress{
+				County:  types.County{Code: types.BucharestCountyCode, Name: "București"},
+				Country: types.Country{Code: "RO", Name: "România"},
+			},
+			wantClient: types.Client{State: types.BucharestCountyCode, City: "Bucuresti"},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "sector")
+			},
+		},
+		{
+			name:       "unknown county and country",
+			client:     types.Client{State: "Atlantis", Country: "Narnia"},
+			wantClient: types.Client{State: "Atlantis", Country: "Narnia"},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorContains(t, err, "country")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.client.NormalizeAddress()
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantClient, tt.client)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrCompanyNotFound = errors.New("company not found")
+
+// CompanyInfo is what a public registry knows about a Romanian company on a date.
+type CompanyInfo struct {
+	// CIF is the fiscal code without the RO prefix.
+	CIF        string
+	Name       string
+	RC         string
+	Address    string
+	City       string
+	County     string
+	PostalCode string
+	Phone      string
+	// Active is false for companies declared inactive or struck off.
+	Active bool
+	// VATPayer is set when the company is registered for VAT.
+	VATPayer bool
+	// VATOnCollection is set when the company applies the VAT on collection system.
+	VATOnCollection bool
+	// EInvoice is set when the company is registered in the RO e-Factura system.
+	EInvoice bool
+	// Date is the day the registry was consulted for.
+	Date Date
+}
+
+// CompanyInfoProvider looks up a company by its fiscal code, with or without the RO prefix. It returns an error
+// wrapping ErrCompanyNotFound when the registry does not know the company.
+type CompanyInfoProvider interface {
+	CompanyInfo(ctx context.Context, cif string, date Date) (CompanyInfo, error)
+}
+
+// FillCompanyInfo looks up the client by its CIF and fills its empty name, registration number and address.
+// VATPayer is always overwritten and the CIF carries the RO prefix exactly when the company is a VAT payer, as
+// Oblio expects. The returned info tells whether the company was active and registered for VAT on date.
+func (c *Client) FillCompanyInfo(ctx context.Context, provider CompanyInfoProvider, date Date) (CompanyInfo, error) {
+	if err := ValidateCIF(c.CIF); err != nil {
+		return CompanyInfo{}, fmt.Errorf("cif: %w", err)
+	}
+
+	info, err := provider.CompanyInfo(ctx, c.CIF, date)
+	if err != nil {
+		return CompanyInfo{}, err
+	}
+
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = strings.TrimSpace(value)
+		}
+	}
+
+	fill(&c.Name, info.Name)
+	fill(&c.RC, info.RC)
+	fill(&c.Address, info.Address)
+	fill(&c.City, info.City)
+	fill(&c.State, info.County)
+	fill(&c.Phone, info.Phone)
+	fill(&c.Country, "România")
+
+	c.VATPayer = Bool(info.VATPayer)
+	c.CIF = info.CIF
+
+	if info.VATPayer {
+		c.CIF = "RO" + info.CIF
+	}
+
+	return info, nil
+}
@@ -0,0 +1,93 @@
+package types_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+type companyInfoFunc func(ctx context.Context, cif string, date types.Date) (types.CompanyInfo, error)
+
+func (fn companyInfoFunc) CompanyInfo(ctx context.Context, cif string, date types.Date) (types.CompanyInfo, error) {
+	return fn(ctx, cif, date)
+}
+
+func TestClient_FillCompanyInfo(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	date := types.NewDate(2024, 5, 7)
+
+	provider := func(info types.CompanyInfo) types.CompanyInfoProvider {
+		return companyInfoFunc(func(_ context.Context, cif string, got types.Date) (types.CompanyInfo, error) {
+			require.Equal(t, date, got)
+
+			return info, nil
+		})
+	}
+
+	t.Run("vat payer", func(t *testing.T) {
+		t.Parallel()
+
+		client := types.Client{CIF: "37311090", Name: "Oblio", Email: "office@oblio.eu"}
+
+		got, err := client.FillCompanyInfo(ctx, provider(types.CompanyInfo{
+			CIF:      "37311090",
+			Name:     "OBLIO SOFTWARE SRL",
+			RC:       "J40/4090/2017",
+			Address:  "Str. Constantin Brâncuşi, nr. 133",
+			City:     "Sector 3",
+			County:   "București",
+			Active:   true,
+			VATPayer: true,
+		}), date)
+		require.NoError(t, err)
+		require.True(t, got.Active)
+		require.Equal(t, types.Client{
+			CIF:      "RO37311090",
+			Name:     "Oblio",
+			RC:       "J40/4090/2017",
+			Address:  "Str. Constantin Brâncuşi, nr. 133",
+			State:    "București",
+			City:     "Sector 3",
+			Country:  "România",
+			Email:    "office@oblio.eu",
+			VATPayer: true,
+		}, client)
+	})
+
+	t.Run("not a vat payer", func(t *testing.T) {
+		t.Parallel()
+
+		client := types.Client{CIF: "RO12345674", VATPayer: true}
+
+		_, err := client.FillCompanyInfo(ctx, provider(types.CompanyInfo{CIF: "12345674", Name: "FIRMA SRL"}), date)
+		require.NoError(t, err)
+		require.Equal(t, "12345674", client.CIF)
+		require.False(t, bool(client.VATPayer))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		client := types.Client{CIF: "19"}
+
+		_, err := client.FillCompanyInfo(ctx, companyInfoFunc(
+			func(context.Context, string, types.Date) (types.CompanyInfo, error) {
+				return types.CompanyInfo{}, types.ErrCompanyNotFound
+			}), date)
+		require.ErrorIs(t, err, types.ErrCompanyNotFound)
+		require.Equal(t, types.Client{CIF: "19"}, client)
+	})
+
+	t.Run("invalid cif", func(t *testing.T) {
+		t.Parallel()
+
+		client := types.Client{CIF: "13"}
+
+		_, err := client.FillCompanyInfo(ctx, provider(types.CompanyInfo{}), date)
+		require.ErrorIs(t, err, types.ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,351 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+var (
+	_ Marshaler     = (*Decimal)(nil)
+	_ query.Encoder = (*Decimal)(nil)
+)
+
+// ErrDivisionByZero is returned when dividing a Decimal by zero.
+var ErrDivisionByZero = errors.New("decimal division by zero")
+
+var (
+	bigTen = big.NewInt(10)
+	bigTwo = big.NewInt(2)
+)
+
+// Decimal is an exact decimal number kept in the textual form Oblio uses for amounts, e.g. "119.00". The
+// empty value means unset and is omitted by omitempty, while "0" is an explicit zero. Arithmetic never goes
+// through floating point and treats an invalid value as zero, while encoding an invalid value fails.
+type Decimal string
+
+func NewDecimal(value int64, scale int) Decimal {
+	return formatDecimal(big.NewInt(value), scale, scale)
+}
+
+func NewDecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+
+	if _, _, ok := parseDecimal(s); !ok {
+		return "", fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	return Decimal(s), nil
+}
+
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func (d Decimal) Valid() bool {
+	_, _, ok := parseDecimal(string(d))
+
+	return ok
+}
+
+func (d Decimal) IsEmpty() bool {
+	return d == ""
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) Sign() int {
+	coef, _ := d.parts()
+
+	return coef.Sign()
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+
+	return normalizeDecimal(a.Add(a, b), scale)
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+
+	return normalizeDecimal(a.Sub(a, b), scale)
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	a, scaleA := d.parts()
+	b, scaleB := other.parts()
+
+	return normalizeDecimal(a.Mul(a, b), scaleA+scaleB)
+}
+
+// Div divides d by other and rounds the result half away from zero to the given number of decimal places.
+// It fails with ErrDivisionByZero when other is zero.
+func (d Decimal) Div(other Decimal, places int) (Decimal, error) {
+	if other.IsZero() {
+		return "", ErrDivisionByZero
+	}
+
+	return d.quo(other, places), nil
+}
+
+// quo divides d by other like Div, other being known not to be zero.
+func (d Decimal) quo(other Decimal, places int) Decimal {
+	a, scaleA := d.parts()
+	b, scaleB := other.parts()
+
+	num := a.Mul(a, pow10(scaleB+places))
+	den := b.Mul(b, pow10(scaleA))
+
+	return formatDecimal(quoRound(num, den), places, places)
+}
+
+func (d Decimal) Neg() Decimal {
+	coef, scale := d.parts()
+
+	return formatDecimal(coef.Neg(coef), scale, scale)
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+
+	return d
+}
+
+// Round rounds d half away from zero and formats it with exactly the given number of decimal places, e.g.
+// Round(SimplePrecision) gives "10.50".
+func (d Decimal) Round(places int) Decimal {
+	coef, scale := d.parts()
+
+	if scale > places {
+		coef = quoRound(coef, pow10(scale-places))
+	} else {
+		coef.Mul(coef, pow10(places-scale))
+	}
+
+	return formatDecimal(coef, places, places)
+}
+
+func (d Decimal) Float64() float64 {
+	coef, scale := d.parts()
+	f, _ := new(big.Rat).SetFrac(coef, pow10(scale)).Float64()
+
+	return f
+}
+
+// Int64 returns the integer part of d.
+func (d Decimal) Int64() int64 {
+	coef, scale := d.parts()
+
+	return coef.Quo(coef, pow10(scale)).Int64()
+}
+
+// String returns d as written, "0" when empty. An invalid value is returned unchanged so it never passes for
+// zero.
+func (d Decimal) String() string {
+	if d.IsEmpty() {
+		return "0"
+	}
+
+	return string(d)
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid() {
+		return nil, fmt.Errorf("invalid decimal: %q", string(d))
+	}
+
+	return json.Marshal(d.String())
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	var a any
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	var s string
+
+	switch v := a.(type) {
+	case nil:
+		return nil
+	case string:
+		s = v
+	case float64:
+		s = string(data)
+	default:
+		return fmt.Errorf("unexpected value: %s", data)
+	}
+
+	if strings.TrimSpace(s) == "" {
+		*d = ""
+
+		return nil
+	}
+
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+
+	*d = v
+
+	return nil
+}
+
+func (d Decimal) EncodeValues(key string, v *url.Values) error {
+	if d.IsEmpty() {
+		return nil
+	}
+
+	if !d.Valid() {
+		return fmt.Errorf("invalid decimal: %q", string(d))
+	}
+
+	v.Set(key, d.String())
+
+	return nil
+}
+
+func (d Decimal) parts() (*big.Int, int) {
+	coef, scale, ok := parseDecimal(string(d))
+	if !ok {
+		return new(big.Int), 0
+	}
+
+	return coef, scale
+}
+
+func parseDecimal(s string) (*big.Int, int, bool) {
+	if s == "" {
+		return new(big.Int), 0, true
+	}
+
+	digits := strings.TrimPrefix(s, "-")
+	intPart, fracPart, hasDot := strings.Cut(digits, ".")
+
+	if intPart == "" && fracPart == "" || hasDot && fracPart == "" {
+		return nil, 0, false
+	}
+
+	for _, r := range intPart + fracPart {
+		if r < '0' || r > '9' {
+			return nil, 0, false
+		}
+	}
+
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, 0, false
+	}
+
+	if strings.HasPrefix(s, "-") {
+		coef.Neg(coef)
+	}
+
+	return coef, len(fracPart), true
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int) {
+	x, scaleX := a.parts()
+	y, scaleY := b.parts()
+
+	switch {
+	case scaleX < scaleY:
+		x.Mul(x, pow10(scaleY-scaleX))
+
+		return x, y, scaleY
+	case scaleY < scaleX:
+		y.Mul(y, pow10(scaleX-scaleY))
+	}
+
+	return x, y, scaleX
+}
+
+// normalizeDecimal formats the result of an arithmetic operation without trailing zeros.
+func normalizeDecimal(coef *big.Int, scale int) Decimal {
+	return formatDecimal(coef, scale, 0)
+}
+
+func formatDecimal(coef *big.Int, scale, minScale int) Decimal {
+	if scale < 0 {
+		coef = new(big.Int).Mul(coef, pow10(-scale))
+		scale = 0
+	}
+
+	s := new(big.Int).Abs(coef).String()
+
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+
+	intPart, fracPart := s[:len(s)-scale], s[len(s)-scale:]
+
+	for len(fracPart) > minScale && strings.HasSuffix(fracPart, "0") {
+		fracPart = fracPart[:len(fracPart)-1]
+	}
+
+	if fracPart != "" {
+		intPart += "." + fracPart
+	}
+
+	if coef.Sign() < 0 {
+		intPart = "-" + intPart
+	}
+
+	return Decimal(intPart)
+}
+
+// quoRound divides num by den rounding half away from zero.
+func quoRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	if r.Sign() == 0 {
+		return q
+	}
+
+	r.Abs(r).Mul(r, bigTwo)
+
+	if r.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
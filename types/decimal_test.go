@@ -0,0 +1,216 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    types.Decimal
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "integer", in: "12", want: "12"},
+		{name: "fraction", in: " 119.00 ", want: "119.00"},
+		{name: "negative", in: "-0.75", want: "-0.75"},
+		{name: "plus sign", in: "+2.5", want: "2.5"},
+		{name: "leading dot", in: ".5", want: ".5"},
+		{name: "trailing dot", in: "5.", wantErr: assert.Error},
+		{name: "letters", in: "12a", wantErr: assert.Error},
+		{name: "exponent", in: "1e3", wantErr: assert.Error},
+		{name: "sign only", in: "-", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := types.ParseDecimal(tt.in)
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = types.Decimal("0.1")
+		b = types.Decimal("0.2")
+	)
+
+	require.Equal(t, types.Decimal("0.3"), a.Add(b))
+	require.Equal(t, types.Decimal("-0.1"), a.Sub(b))
+	require.Equal(t, types.Decimal("0.02"), a.Mul(b))
+	require.Equal(t, types.Decimal("299.75"), types.Decimal("119.90").Mul("2.5"))
+	require.Equal(t, types.Decimal("3"), types.Decimal("1.50").Add("1.50"))
+	require.Equal(t, types.Decimal("2"), types.Decimal("").Add("2"))
+	require.Equal(t, types.Decimal("2.50"), types.NewDecimal(250, 2))
+	require.Equal(t, types.Decimal("1.5"), types.Decimal("-1.5").Abs())
+	require.Equal(t, types.Decimal("-1.5"), types.Decimal("1.5").Neg())
+	require.Equal(t, int64(2), types.Decimal("2.99").Int64())
+	require.InDelta(t, 2.99, types.Decimal("2.99").Float64(), 0.0001)
+	require.True(t, types.Decimal("1.10").Equal("1.1"))
+	require.Equal(t, -1, types.Decimal("1.09").Cmp("1.1"))
+	require.True(t, types.Decimal("0.000").IsZero())
+	require.False(t, types.Decimal("abc").Valid())
+	require.True(t, types.Decimal("abc").IsZero())
+}
+
+func TestDecimal_Round(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in     types.Decimal
+		places int
+		want   types.Decimal
+	}{
+		{in: "1.005", places: types.SimplePrecision, want: "1.01"},
+		{in: "1.0049", places: types.SimplePrecision, want: "1.00"},
+		{in: "-1.005", places: types.SimplePrecision, want: "-1.01"},
+		{in: "2.5", places: 0, want: "3"},
+		{in: "10.5", places: types.SimplePrecision, want: "10.50"},
+		{in: "10.123456", places: types.DoublePrecision, want: "10.1235"},
+		{in: "", places: types.SimplePrecision, want: "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.in), func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.want, tt.in.Round(tt.places))
+		})
+	}
+}
+
+func TestDecimal_Div(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b    types.Decimal
+		places  int
+		want    types.Decimal
+		wantErr error
+	}{
+		{a: "0.1", b: "0.2", places: types.DoublePrecision, want: "0.5000"},
+		{a: "1", b: "3", places: types.SimplePrecision, want: "0.33"},
+		{a: "-2", b: "3", places: types.SimplePrecision, want: "-0.67"},
+		{a: "1", b: "0.00", places: types.SimplePrecision, wantErr: types.ErrDivisionByZero},
+		{a: "1", b: "", places: types.SimplePrecision, wantErr: types.ErrDivisionByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.a+"/"+tt.b), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.a.Div(tt.b, tt.places)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecimal_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Value    types.Decimal `json:"value"`
+		Optional types.Decimal `json:"optional,omitempty"`
+	}
+
+	got, err := json.Marshal(args{Value: "2.50"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value":"2.50"}`, string(got))
+
+	got, err = json.Marshal(args{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"value":"0"}`, string(got))
+
+	_, err = json.Marshal(args{Value: "12,50"})
+	require.ErrorContains(t, err, `invalid decimal: "12,50"`)
+}
+
+func TestDecimal_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "2.50", types.Decimal("2.50").String())
+	require.Equal(t, "0", types.Decimal("").String())
+	require.Equal(t, "12,50", types.Decimal("12,50").String())
+}
+
+func TestDecimal_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	type Got struct {
+		Value types.Decimal `json:"value"`
+	}
+
+	tests := []struct {
+		name    string
+		js      string
+		want    types.Decimal
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "string", js: `{"value":"21000.0000"}`, want: "21000.0000"},
+		{name: "number", js: `{"value":2.5}`, want: "2.5"},
+		{name: "integer", js: `{"value":19}`, want: "19"},
+		{name: "empty", js: `{"value":""}`},
+		{name: "null", js: `{"value":null}`},
+		{name: "invalid", js: `{"value":"foo"}`, wantErr: assert.Error},
+		{name: "bool", js: `{"value":true}`, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Got{}
+			err := json.Unmarshal([]byte(tt.js), &got)
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Value)
+		})
+	}
+}
+
+func TestDecimal_EncodeValues(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		Value types.Decimal `url:"value,omitempty"`
+	}
+
+	got, err := query.Values(args{Value: "0.75"})
+	require.NoError(t, err)
+	require.Equal(t, "value=0.75", got.Encode())
+
+	got, err = query.Values(args{})
+	require.NoError(t, err)
+	require.Empty(t, got.Encode())
+
+	_, err = query.Values(args{Value: "12,50"})
+	require.Error(t, err)
+}
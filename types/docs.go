@@ -1,10 +1,13 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 )
 
+var ErrInvalidArgument = errors.New("invalid argument")
+
 type DocumentRow interface {
 	isDocumentRow()
 }
@@ -21,13 +24,33 @@ var _ DocumentRow = (*Discount)(nil)
 type Discount struct {
 	RefItem          string       `json:"refItem,omitempty"`
 	Name             string       `json:"name,omitempty"`
-	Discount         int          `json:"discount,omitempty"`
+	Discount         Decimal      `json:"discount,omitempty"`
 	DiscountType     DiscountType `json:"discountType,omitempty"`
 	DiscountAllAbove Bool         `json:"discountAllAbove,omitempty"`
 }
 
 func (d *Discount) isDocumentRow() {}
 
+func (d *Discount) Validate() error {
+	var errs []error
+
+	if !d.Discount.Valid() || d.Discount.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("discount %q is not a positive amount: %w", d.Discount, ErrInvalidArgument))
+	}
+
+	switch d.DiscountType {
+	case PercentageDiscountType:
+		if d.Discount.Cmp("100") > 0 {
+			errs = append(errs, fmt.Errorf("discount %q is over 100%%: %w", d.Discount, ErrInvalidArgument))
+		}
+	case FlatDiscountType:
+	default:
+		errs = append(errs, fmt.Errorf("discountType %q is unknown: %w", d.DiscountType, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
 type CollectType string
 
 const (
@@ -46,25 +69,54 @@ type Collect struct {
 	Type           CollectType `json:"type,omitempty"`
 	SeriesName     string      `json:"seriesName,omitempty"`
 	DocumentNumber string      `json:"documentNumber,omitempty"`
-	Value          string      `json:"value,omitempty"`
+	Value          Decimal     `json:"value,omitempty"`
 	IssueDate      Date        `json:"issueDate,omitempty"`
 	Mentions       string      `json:"mentions,omitempty"`
 }
 
+// Validate checks the collect type and the fields the type requires: receipts are numbered from the receipt
+// series, while the other documents, except card and other cash or bank collects, need their number.
+func (c *Collect) Validate() error {
+	var errs []error
+
+	switch c.Type {
+	case ReceiptCollectType:
+		if c.SeriesName == "" {
+			errs = append(errs, fmt.Errorf("seriesName is empty: %w", ErrInvalidArgument))
+		}
+	case TaxReceiptCollectType, PaymentOrderCollectType, PostalOrderCollectType, CheckCollectType,
+		PromissoryNoteCollectType:
+		if c.DocumentNumber == "" {
+			errs = append(errs, fmt.Errorf("documentNumber is empty: %w", ErrInvalidArgument))
+		}
+	case CardCollectType, CashCollectType, BankCollectType:
+	case "":
+		errs = append(errs, fmt.Errorf("type is empty: %w", ErrInvalidArgument))
+	default:
+		errs = append(errs, fmt.Errorf("type %q is unknown: %w", c.Type, ErrInvalidArgument))
+	}
+
+	if !c.Value.Valid() || !c.Value.IsEmpty() && c.Value.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("value %q is not a positive amount: %w", c.Value, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
 type ReferenceDocument struct {
-	Type       string `json:"type,omitempty"`
-	SeriesName string `json:"seriesName,omitempty"`
-	Number     int    `json:"number,omitempty"`
+	Type       DocumentType `json:"type,omitempty"`
+	SeriesName string       `json:"seriesName,omitempty"`
+	Number     int          `json:"number,omitempty"`
 }
 
 type Document struct {
-	DocumentType string    `json:"documentType,omitempty"`
-	SeriesName   string    `json:"seriesName,omitempty"`
-	Number       string    `json:"number,omitempty"`
-	Link         string    `json:"link,omitempty"`
-	EInvoice     string    `json:"einvoice,omitempty"`
-	Total        string    `json:"total,omitempty"`
-	Collects     []Collect `json:"collects,omitempty"`
+	DocumentType DocumentType `json:"documentType,omitempty"`
+	SeriesName   string       `json:"seriesName,omitempty"`
+	Number       string       `json:"number,omitempty"`
+	Link         string       `json:"link,omitempty"`
+	EInvoice     string       `json:"einvoice,omitempty"`
+	Total        Decimal      `json:"total,omitempty"`
+	Collects     []Collect    `json:"collects,omitempty"`
 }
 
 func (d *Document) GetID() (string, error) {
@@ -83,31 +135,31 @@ func (d *Document) GetID() (string, error) {
 }
 
 type Invoice struct {
-	ID                 string    `json:"id,omitempty"`
-	Draft              Bool      `json:"draft,omitempty"`
-	Canceled           Bool      `json:"canceled,omitempty"`
-	Collected          Bool      `json:"collected,omitempty"`
-	SeriesName         string    `json:"seriesName,omitempty"`
-	Number             string    `json:"number,omitempty"`
-	IssueDate          Date      `json:"issueDate,omitempty"`
-	DueDate            Date      `json:"dueDate,omitempty"`
-	Precision          Int       `json:"precision,omitempty"`
-	Currency           string    `json:"currency,omitempty"`
-	ExchangeRate       string    `json:"exchangeRate,omitempty"`
-	Total              string    `json:"total,omitempty"`
-	IssuerName         string    `json:"issuerName,omitempty"`
-	IssuerID           string    `json:"issuerId,omitempty"`
-	NoticeNumber       string    `json:"noticeNumber,omitempty"`
-	DeputyName         string    `json:"deputyName,omitempty"`
-	DeputyIdentityCard string    `json:"deputyIdentityCard,omitempty"`
-	DeputyAuto         string    `json:"deputyAuto,omitempty"`
-	Mentions           string    `json:"mentions,omitempty"`
-	UseStock           Bool      `json:"useStock,omitempty"`
-	Type               string    `json:"type,omitempty"`
-	Link               string    `json:"link,omitempty"`
-	EInvoice           string    `json:"einvoice,omitempty"`
-	Client             Client    `json:"client,omitempty"`
-	Products           []Product `json:"products,omitempty"`
+	ID                 string       `json:"id,omitempty"`
+	Draft              Bool         `json:"draft,omitempty"`
+	Canceled           Bool         `json:"canceled,omitempty"`
+	Collected          Bool         `json:"collected,omitempty"`
+	SeriesName         string       `json:"seriesName,omitempty"`
+	Number             string       `json:"number,omitempty"`
+	IssueDate          Date         `json:"issueDate,omitempty"`
+	DueDate            Date         `json:"dueDate,omitempty"`
+	Precision          Int          `json:"precision,omitempty"`
+	Currency           string       `json:"currency,omitempty"`
+	ExchangeRate       Decimal      `json:"exchangeRate,omitempty"`
+	Total              Decimal      `json:"total,omitempty"`
+	IssuerName         string       `json:"issuerName,omitempty"`
+	IssuerID           string       `json:"issuerId,omitempty"`
+	NoticeNumber       string       `json:"noticeNumber,omitempty"`
+	DeputyName         string       `json:"deputyName,omitempty"`
+	DeputyIdentityCard string       `json:"deputyIdentityCard,omitempty"`
+	DeputyAuto         string       `json:"deputyAuto,omitempty"`
+	Mentions           string       `json:"mentions,omitempty"`
+	UseStock           Bool         `json:"useStock,omitempty"`
+	Type               DocumentType `json:"type,omitempty"`
+	Link               string       `json:"link,omitempty"`
+	EInvoice           string       `json:"einvoice,omitempty"`
+	Client             Client       `json:"client,omitempty"`
+	Products           []Product    `json:"products,omitempty"`
 }
 
 const (
@@ -115,15 +167,75 @@ const (
 	DoublePrecision = 4
 )
 
-var _ DocumentRow = (*ProductRow)(nil)
+var _ DocumentRow = (*LineItem)(nil)
+
+// LineItem is a product or service line of a document.
+type LineItem struct {
+	Name          string  `json:"name,omitempty"`
+	Code          string  `json:"code,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Price         Decimal `json:"price,omitempty"`
+	MeasuringUnit string  `json:"measuringUnit,omitempty"`
+	Currency      string  `json:"currency,omitempty"`
+	VATName       string  `json:"vatName,omitempty"`
+	VATPercentage Decimal `json:"vatPercentage,omitempty"`
+	// VATIncluded tells whether Price includes VAT. Oblio includes it when nil.
+	VATIncluded  *Bool        `json:"vatIncluded,omitempty"`
+	ProductType  ProductType  `json:"productType,omitempty"`
+	Management   string       `json:"management,omitempty"`
+	Quantity     Decimal      `json:"quantity,omitempty"`
+	Discount     Decimal      `json:"discount,omitempty"`
+	DiscountType DiscountType `json:"discountType,omitempty"`
+	Save         Bool         `json:"save,omitempty"`
+}
 
-type ProductRow struct {
-	Product
+func (l *LineItem) isDocumentRow() {}
 
-	Item       string `json:"item,omitempty"`
-	Management string `json:"management,omitempty"`
-	Quantity   int    `json:"quantity,omitempty"`
-	Save       Bool   `json:"save,omitempty"`
+// PriceIncludesVAT reports whether Price includes VAT, as Oblio does by default.
+func (l *LineItem) PriceIncludesVAT() bool {
+	return l.VATIncluded == nil || bool(*l.VATIncluded)
 }
 
-func (p *ProductRow) isDocumentRow() {}
+func (l *LineItem) Validate() error {
+	var errs []error
+
+	if l.Name == "" {
+		errs = append(errs, fmt.Errorf("name is empty: %w", ErrInvalidArgument))
+	}
+
+	if !l.Price.Valid() || l.Price.Sign() < 0 {
+		errs = append(errs, fmt.Errorf("price %q is not a positive amount: %w", l.Price, ErrInvalidArgument))
+	}
+
+	if !l.Quantity.Valid() || !l.Quantity.IsEmpty() && l.Quantity.IsZero() {
+		errs = append(errs, fmt.Errorf("quantity %q is not a non-zero amount: %w", l.Quantity, ErrInvalidArgument))
+	}
+
+	if !l.VATPercentage.Valid() || l.VATPercentage.Sign() < 0 || l.VATPercentage.Cmp(hundred) > 0 {
+		errs = append(errs, fmt.Errorf("vatPercentage %q is out of range: %w", l.VATPercentage, ErrInvalidArgument))
+	}
+
+	if l.ProductType != "" && !l.ProductType.Valid() {
+		errs = append(errs, fmt.Errorf("productType %q is unknown: %w", l.ProductType, ErrInvalidArgument))
+	}
+
+	if l.Discount.IsEmpty() {
+		return errors.Join(errs...)
+	}
+
+	if !l.Discount.Valid() || l.Discount.Sign() < 0 {
+		errs = append(errs, fmt.Errorf("discount %q is not a positive amount: %w", l.Discount, ErrInvalidArgument))
+	}
+
+	switch l.DiscountType {
+	case PercentageDiscountType:
+		if l.Discount.Cmp("100") > 0 {
+			errs = append(errs, fmt.Errorf("discount %q is over 100%%: %w", l.Discount, ErrInvalidArgument))
+		}
+	case FlatDiscountType:
+	default:
+		errs = append(errs, fmt.Errorf("discountType %q is unknown: %w", l.DiscountType, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,177 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestLineItem_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		item    types.LineItem
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "valid",
+			item: types.LineItem{
+				Name:         "Consultanta",
+				Price:        "150.00",
+				Quantity:     "2.5",
+				Discount:     "12.5",
+				DiscountType: types.PercentageDiscountType,
+			},
+		},
+		{
+			name: "fractional vat percentage",
+			item: types.LineItem{
+				Name:          "Consultanta",
+				Price:         "150.00",
+				VATPercentage: "25.5",
+			},
+		},
+		{
+			name: "storno quantity",
+			item: types.LineItem{
+				Name:     "Consultanta",
+				Price:    "150.00",
+				Quantity: "-1",
+			},
+		},
+		{
+			name: "missing name",
+			item: types.LineItem{
+				Price: "150.00",
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "name")
+			},
+		},
+		{
+			name: "invalid amounts",
+			item: types.LineItem{
+				Name:          "Consultanta",
+				Price:         "-1",
+				Quantity:      "0",
+				VATPercentage: "101",
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorContains(t, err, "price") &&
+					assert.ErrorContains(t, err, "quantity") &&
+					assert.ErrorContains(t, err, "vatPercentage")
+			},
+		},
+		{
+			name: "discount over 100%",
+			item: types.LineItem{
+				Name:         "Consultanta",
+				Discount:     "120",
+				DiscountType: types.PercentageDiscountType,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "discount without type",
+			item: types.LineItem{
+				Name:     "Consultanta",
+				Discount: "10",
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.item.Validate()
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLineItem_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	got, err := json.Marshal(&types.LineItem{
+		Name:     "Ore programare",
+		Price:    "100",
+		Quantity: "2.5",
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Ore programare","price":"100","quantity":"2.5"}`, string(got))
+
+	got, err = json.Marshal(&types.LineItem{
+		Name:        "Ore programare",
+		Price:       "100",
+		VATIncluded: types.NewBool(false),
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"Ore programare","price":"100","vatIncluded":"0"}`, string(got))
+}
+
+func TestCollect_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		collect types.Collect
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "receipt",
+			collect: types.Collect{Type: types.ReceiptCollectType, SeriesName: "CHT", Value: "119"},
+		},
+		{
+			name:    "card",
+			collect: types.Collect{Type: types.CardCollectType},
+		},
+		{
+			name:    "receipt without series",
+			collect: types.Collect{Type: types.ReceiptCollectType},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "seriesName")
+			},
+		},
+		{
+			name:    "payment order without number",
+			collect: types.Collect{Type: types.PaymentOrderCollectType, Value: "-1"},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorContains(t, err, "documentNumber") && assert.ErrorContains(t, err, "value")
+			},
+		},
+		{
+			name:    "unknown type",
+			collect: types.Collect{Type: "Barter"},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.collect.Validate()
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	_ enumValue = CollectType("")
+	_ enumValue = ProductType("")
+	_ enumValue = DiscountType("")
+	_ enumValue = DocumentType("")
+	_ enumValue = ManagementType("")
+)
+
+type enumValue interface {
+	Valid() bool
+	isEnum()
+}
+
+// ValidateEnums reports, wrapped in ErrInvalidArgument, the enum values in v Oblio does not know, e.g. a typo
+// such as CollectType("Ordin plata"). v is walked through its exported fields, pointers, slices and interfaces;
+// empty values are allowed.
+func ValidateEnums(v any) error {
+	var errs []error
+
+	walkEnums(reflect.ValueOf(v), "", &errs)
+
+	return errors.Join(errs...)
+}
+
+func walkEnums(v reflect.Value, path string, errs *[]error) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkEnums(v.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if field.Anonymous {
+				walkEnums(v.Field(i), path, errs)
+
+				continue
+			}
+
+			name := field.Name
+			if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag != "" && tag != "-" {
+				name = tag
+			}
+
+			if path != "" {
+				name = path + "." + name
+			}
+
+			walkEnums(v.Field(i), name, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walkEnums(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.String:
+		if e, ok := v.Interface().(enumValue); ok && v.Len() > 0 && !e.Valid() {
+			*errs = append(*errs, fmt.Errorf("%s %q is unknown: %w", path, v.String(), ErrInvalidArgument))
+		}
+	}
+}
+
+type DocumentType string
+
+const (
+	InvoiceDocumentType  DocumentType = "Factura"
+	ProformaDocumentType DocumentType = "Proforma"
+	NoticeDocumentType   DocumentType = "Aviz"
+	ReceiptDocumentType  DocumentType = "Chitanta"
+)
+
+type ManagementType string
+
+const (
+	QuantitativeManagementType ManagementType = "Cantitativ Valorica"
+	GlobalManagementType       ManagementType = "Global Valorica"
+)
+
+var (
+	collectTypes = newEnum("collect type", map[CollectType][]string{
+		ReceiptCollectType:        {"receipt"},
+		TaxReceiptCollectType:     {"tax receipt", "fiscal receipt"},
+		CashCollectType:           {"cash"},
+		PaymentOrderCollectType:   {"payment order", "bank transfer"},
+		PostalOrderCollectType:    {"postal order"},
+		CardCollectType:           {"card"},
+		CheckCollectType:          {"check", "cheque"},
+		PromissoryNoteCollectType: {"promissory note"},
+		BankCollectType:           {"bank"},
+	})
+	productTypes = newEnum("product type", map[ProductType][]string{
+		MerchandiseProductType:  {"merchandise", "goods"},
+		ServiceProductType:      {"service"},
+		RawProductType:          {"raw materials"},
+		ConsumableProductType:   {"consumables"},
+		SemiProductType:         {"semi-finished products"},
+		FinishedProductType:     {"finished product"},
+		WasteProductType:        {"waste"},
+		AgriculturalProductType: {"agricultural products"},
+		LivestockProductType:    {"livestock"},
+		PackingProductType:      {"packaging"},
+		InventoryProductType:    {"inventory"},
+		NoneProductType:         {"none"},
+	})
+	discountTypes = newEnum("discount type", map[DiscountType][]string{
+		PercentageDiscountType: {"percentage", "percent"},
+		FlatDiscountType:       {"flat", "amount"},
+	})
+	documentTypes = newEnum("document type", map[DocumentType][]string{
+		InvoiceDocumentType:  {"invoice"},
+		ProformaDocumentType: {"proforma", "proforma invoice"},
+		NoticeDocumentType:   {"notice", "delivery notice"},
+		ReceiptDocumentType:  {"receipt"},
+	})
+	managementTypes = newEnum("management type", map[ManagementType][]string{
+		QuantitativeManagementType: {"quantitative"},
+		GlobalManagementType:       {"global"},
+	})
+)
+
+func (t CollectType) Valid() bool {
+	return collectTypes.valid(t)
+}
+
+func (t CollectType) String() string {
+	return string(t)
+}
+
+func (t CollectType) isEnum() {}
+
+// ParseCollectType accepts the Oblio value or its English name, e.g. "payment order", in any case.
+func ParseCollectType(s string) (CollectType, error) {
+	return collectTypes.parse(s)
+}
+
+func (t ProductType) Valid() bool {
+	return productTypes.valid(t)
+}
+
+func (t ProductType) String() string {
+	return string(t)
+}
+
+func (t ProductType) isEnum() {}
+
+// ParseProductType accepts the Oblio value or its English name, e.g. "service", in any case.
+func ParseProductType(s string) (ProductType, error) {
+	return productTypes.parse(s)
+}
+
+func (t DiscountType) Valid() bool {
+	return discountTypes.valid(t)
+}
+
+func (t DiscountType) String() string {
+	return string(t)
+}
+
+func (t DiscountType) isEnum() {}
+
+// ParseDiscountType accepts the Oblio value or its English name, e.g. "percentage", in any case.
+func ParseDiscountType(s string) (DiscountType, error) {
+	return discountTypes.parse(s)
+}
+
+func (t DocumentType) Valid() bool {
+	return documentTypes.valid(t)
+}
+
+func (t DocumentType) String() string {
+	return string(t)
+}
+
+func (t DocumentType) isEnum() {}
+
+// ParseDocumentType accepts the Oblio value or its English name, e.g. "invoice", in any case.
+func ParseDocumentType(s string) (DocumentType, error) {
+	return documentTypes.parse(s)
+}
+
+func (t ManagementType) Valid() bool {
+	return managementTypes.valid(t)
+}
+
+func (t ManagementType) String() string {
+	return string(t)
+}
+
+func (t ManagementType) isEnum() {}
+
+// ParseManagementType accepts the Oblio value or its English name, e.g. "quantitative", in any case.
+func ParseManagementType(s string) (ManagementType, error) {
+	return managementTypes.parse(s)
+}
+
+type enum[T ~string] struct {
+	name   string
+	values map[T]struct{}
+	lookup map[string]T
+}
+
+func newEnum[T ~string](name string, aliases map[T][]string) enum[T] {
+	e := enum[T]{
+		name:   name,
+		values: make(map[T]struct{}, len(aliases)),
+		lookup: make(map[string]T),
+	}
+
+	for value, names := range aliases {
+		e.values[value] = struct{}{}
+		e.lookup[enumKey(string(value))] = value
+
+		for _, name := range names {
+			e.lookup[enumKey(name)] = value
+		}
+	}
+
+	return e
+}
+
+func (e enum[T]) valid(v T) bool {
+	_, ok := e.values[v]
+
+	return ok
+}
+
+func (e enum[T]) parse(s string) (T, error) {
+	v, ok := e.lookup[enumKey(s)]
+	if !ok {
+		return "", fmt.Errorf("%s %q is unknown: %w", e.name, s, ErrInvalidArgument)
+	}
+
+	return v, nil
+}
+
+func enumKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(diacritics.Replace(s))), " ")
+}
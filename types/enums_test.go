@@ -0,0 +1,104 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestParseEnums(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collect type", func(t *testing.T) {
+		t.Parallel()
+
+		for in, want := range map[string]types.CollectType{
+			"Ordin de plata": types.PaymentOrderCollectType,
+			"payment order":  types.PaymentOrderCollectType,
+			"  Tax  Receipt": types.TaxReceiptCollectType,
+			"CHITANȚA":       types.ReceiptCollectType,
+		} {
+			got, err := types.ParseCollectType(in)
+			require.NoError(t, err, in)
+			require.Equal(t, want, got, in)
+		}
+
+		_, err := types.ParseCollectType("Ordin plata")
+		require.ErrorIs(t, err, types.ErrInvalidArgument)
+	})
+
+	t.Run("product type", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := types.ParseProductType("Service")
+		require.NoError(t, err)
+		require.Equal(t, types.ServiceProductType, got)
+	})
+
+	t.Run("discount type", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := types.ParseDiscountType("percentage")
+		require.NoError(t, err)
+		require.Equal(t, types.PercentageDiscountType, got)
+	})
+
+	t.Run("document type", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := types.ParseDocumentType("delivery notice")
+		require.NoError(t, err)
+		require.Equal(t, types.NoticeDocumentType, got)
+	})
+
+	t.Run("management type", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := types.ParseManagementType("cantitativ valorica")
+		require.NoError(t, err)
+		require.Equal(t, types.QuantitativeManagementType, got)
+	})
+}
+
+func TestEnums_Valid(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, types.CardCollectType.Valid())
+	require.False(t, types.CollectType("Ordin plata").Valid())
+	require.True(t, types.NoneProductType.Valid())
+	require.False(t, types.ProductType("").Valid())
+	require.True(t, types.FlatDiscountType.Valid())
+	require.True(t, types.InvoiceDocumentType.Valid())
+	require.True(t, types.GlobalManagementType.Valid())
+	require.Equal(t, "Factura", types.InvoiceDocumentType.String())
+}
+
+func TestValidateEnums(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, types.ValidateEnums(&types.Collect{Type: types.PaymentOrderCollectType}))
+	require.NoError(t, types.ValidateEnums(types.Collect{}))
+	require.NoError(t, types.ValidateEnums(nil))
+
+	err := types.ValidateEnums(&struct {
+		Collect  types.Collect       `json:"collect"`
+		Products []types.DocumentRow `json:"products,omitempty"`
+	}{
+		Collect: types.Collect{Type: "Ordin plata"},
+		Products: []types.DocumentRow{
+			&types.LineItem{Name: "Carte", ProductType: types.ServiceProductType},
+			&types.Discount{DiscountType: "procent"},
+			(*types.LineItem)(nil),
+		},
+	})
+	require.ErrorIs(t, err, types.ErrInvalidArgument)
+	require.ErrorContains(t, err, `collect.type "Ordin plata" is unknown`)
+	require.ErrorContains(t, err, `products[1].discountType "procent" is unknown`)
+	require.NotContains(t, err.Error(), "products[0]")
+
+	got, err := json.Marshal(types.Collect{Type: "Ordin plata"})
+	require.NoError(t, err)
+	require.Contains(t, string(got), `"type":"Ordin plata"`)
+}
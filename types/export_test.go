@@ -0,0 +1,3 @@
+package types
+
+var RomanianOffsetAt = romanianOffsetAt
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	cifControlKey = "753217532"
+	cnpControlKey = "279146358279"
+)
+
+var (
+	cifPattern = regexp.MustCompile(`^\d{2,10}$`)
+	cnpPattern = regexp.MustCompile(`^[1-9]\d{12}$`)
+)
+
+// euVATPatterns are the formats of the EU VAT numbers without their country prefix. Greece uses EL and Northern
+// Ireland XI.
+var euVATPatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": cifPattern,
+	"SE": regexp.MustCompile(`^\d{12}$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+// ValidateCIF checks a Romanian fiscal code (CUI/CIF), with or without the RO prefix, including its control digit.
+func ValidateCIF(cif string) error {
+	digits := strings.TrimPrefix(normalizeFiscalCode(cif), "RO")
+
+	if !cifPattern.MatchString(digits) {
+		return fmt.Errorf("cif %q is not 2 to 10 digits: %w", cif, ErrInvalidArgument)
+	}
+
+	body := strings.Repeat("0", len(cifControlKey)-len(digits)+1) + digits[:len(digits)-1]
+	control := controlSum(body, cifControlKey) * 10 % 11 % 10
+
+	if int(digits[len(digits)-1]-'0') != control {
+		return fmt.Errorf("cif %q has a wrong control digit: %w", cif, ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// ValidateCNP checks a Romanian personal numeric code: its length, county, birth date and control digit.
+func ValidateCNP(cnp string) error {
+	cnp = normalizeFiscalCode(cnp)
+
+	if !cnpPattern.MatchString(cnp) {
+		return fmt.Errorf("cnp %q is not 13 digits: %w", cnp, ErrInvalidArgument)
+	}
+
+	centuries := map[byte][]int{'1': {1900}, '2': {1900}, '3': {1800}, '4': {1800}, '5': {2000}, '6': {2000}}[cnp[0]]
+	if centuries == nil {
+		// Residents and foreigners, the century is not encoded.
+		centuries = []int{1900, 2000}
+	}
+
+	if !slices.ContainsFunc(centuries, func(century int) bool {
+		return isDate(century+atoi(cnp[1:3]), atoi(cnp[3:5]), atoi(cnp[5:7]))
+	}) {
+		return fmt.Errorf("cnp %q has an invalid birth date: %w", cnp, ErrInvalidArgument)
+	}
+
+	// Counties are 01-40, Bucharest sectors 41-48, Călărași and Giurgiu 51-52, foreigners 70.
+	if county := atoi(cnp[7:9]); county < 1 || county > 48 && county != 51 && county != 52 && county != 70 {
+		return fmt.Errorf("cnp %q has an invalid county code: %w", cnp, ErrInvalidArgument)
+	}
+
+	control := controlSum(cnp[:12], cnpControlKey) % 11
+	if control == 10 {
+		control = 1
+	}
+
+	if int(cnp[12]-'0') != control {
+		return fmt.Errorf("cnp %q has a wrong control digit: %w", cnp, ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+func isDate(year, month, day int) bool {
+	return month >= 1 && month <= 12 && time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Day() == day
+}
+
+// ValidateEUVAT checks the format of an EU VAT number prefixed by its country code. Romanian numbers are also
+// checked for their control digit.
+func ValidateEUVAT(vat string) error {
+	code := normalizeFiscalCode(vat)
+
+	if len(code) < 2 {
+		return fmt.Errorf("vat number %q has no country prefix: %w", vat, ErrInvalidArgument)
+	}
+
+	country, number := code[:2], code[2:]
+
+	pattern, ok := euVATPatterns[country]
+	if !ok {
+		return fmt.Errorf("vat number %q has an unknown country prefix: %w", vat, ErrInvalidArgument)
+	}
+
+	if country == "RO" {
+		return ValidateCIF(code)
+	}
+
+	if !pattern.MatchString(number) {
+		return fmt.Errorf("vat number %q does not match the %s format: %w", vat, country, ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// hasForeignEUVATPrefix reports whether code starts with the country prefix of a non Romanian EU VAT number.
+func hasForeignEUVATPrefix(code string) bool {
+	code = normalizeFiscalCode(code)
+	if len(code) < 2 || code[:2] == "RO" {
+		return false
+	}
+
+	_, ok := euVATPatterns[code[:2]]
+
+	return ok
+}
+
+// validateClientCode checks the fiscal code of a client. Foreign EU clients are identified by their VAT number,
+// Romanian ones by their CIF or, for individuals, by their CNP or 13 zeros when it is unknown. The codes of
+// clients from outside the EU are not checked.
+func validateClientCode(code, country string) error {
+	if hasForeignEUVATPrefix(code) {
+		return ValidateEUVAT(code)
+	}
+
+	switch strings.ToUpper(country) {
+	case "", "RO", "ROMANIA", "ROMÂNIA":
+	default:
+		return nil
+	}
+
+	switch digits := strings.TrimPrefix(normalizeFiscalCode(code), "RO"); {
+	case digits == strings.Repeat("0", 13):
+		return nil
+	case len(digits) == 13:
+		return ValidateCNP(digits)
+	default:
+		return ValidateCIF(code)
+	}
+}
+
+func normalizeFiscalCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), ""))
+}
+
+func controlSum(digits, key string) int {
+	sum := 0
+
+	for i := range digits {
+		sum += int(digits[i]-'0') * int(key[i]-'0')
+	}
+
+	return sum
+}
+
+func atoi(digits string) int {
+	n := 0
+
+	for i := range digits {
+		n = n*10 + int(digits[i]-'0')
+	}
+
+	return n
+}
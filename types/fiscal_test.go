@@ -0,0 +1,155 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestValidateCIF(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cif   string
+		valid bool
+	}{
+		{cif: "RO37311090", valid: true},
+		{cif: "37311090", valid: true},
+		{cif: "ro 37311090", valid: true},
+		{cif: "RO12345674", valid: true},
+		{cif: "RO12345678"},
+		{cif: "1"},
+		{cif: "12345678901"},
+		{cif: "RO3731109A"},
+		{cif: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cif, func(t *testing.T) {
+			t.Parallel()
+
+			err := types.ValidateCIF(tt.cif)
+
+			if tt.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, types.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestValidateCNP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cnp   string
+		valid bool
+	}{
+		{cnp: "1820913326910", valid: true},
+		{cnp: "2960229400011", valid: true},
+		{cnp: "7000229400012", valid: true},
+		{cnp: "1820913486919", valid: true},
+		{cnp: "1820913496916"},
+		{cnp: "1820913326911"},
+		{cnp: "1820230326911"},
+		{cnp: "2970229400011"},
+		{cnp: "1820913606915"},
+		{cnp: "0820913326910"},
+		{cnp: "182091332691"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cnp, func(t *testing.T) {
+			t.Parallel()
+
+			err := types.ValidateCNP(tt.cnp)
+
+			if tt.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, types.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestValidateEUVAT(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		vat   string
+		valid bool
+	}{
+		{vat: "DE123456789", valid: true},
+		{vat: "ATU12345678", valid: true},
+		{vat: "NL123456789B01", valid: true},
+		{vat: "FRIO123456789"},
+		{vat: "FRXX123456789", valid: true},
+		{vat: "EL123456789", valid: true},
+		{vat: "RO37311090", valid: true},
+		{vat: "RO37311091"},
+		{vat: "GR123456789"},
+		{vat: "DE12345678"},
+		{vat: "X"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vat, func(t *testing.T) {
+			t.Parallel()
+
+			err := types.ValidateEUVAT(tt.vat)
+
+			if tt.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, types.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestClient_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		client types.Client
+		valid  bool
+	}{
+		{name: "company", client: types.Client{CIF: "RO37311090", IBAN: "RO21INGB0000999901234567"}, valid: true},
+		{name: "individual", client: types.Client{CIF: "1820913326910", Country: "Romania"}, valid: true},
+		{name: "individual without cnp", client: types.Client{CIF: "0000000000000", Name: "Ion Popescu"}, valid: true},
+		{name: "name only", client: types.Client{Name: "Ion Popescu"}, valid: true},
+		{name: "eu company", client: types.Client{CIF: "DE123456789", Country: "Germania"}, valid: true},
+		{name: "non eu company", client: types.Client{CIF: "GB-123", Country: "Marea Britanie"}, valid: true},
+		{name: "wrong cif", client: types.Client{CIF: "RO37311091"}},
+		{name: "wrong cnp", client: types.Client{CIF: "1820913326911"}},
+		{name: "wrong eu vat", client: types.Client{CIF: "DE1234", Country: "Germania"}},
+		{name: "anonymous", client: types.Client{}},
+		{name: "wrong iban", client: types.Client{CIF: "RO37311090", IBAN: "RO22INGB0000999901234567"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.client.Validate()
+
+			if tt.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, types.ErrInvalidArgument)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"slices"
+	"time"
+)
+
+type Holiday struct {
+	Date Date
+	Name string
+}
+
+// fixedHoliday is a public holiday on the same day every year, observed from the year since.
+type fixedHoliday struct {
+	month time.Month
+	day   int
+	name  string
+	since int
+}
+
+// easterHoliday is a public holiday a number of days after the Orthodox Easter Sunday, observed from the
+// year since.
+type easterHoliday struct {
+	offset int
+	name   string
+	since  int
+}
+
+var fixedHolidays = []fixedHoliday{
+	{month: time.January, day: 1, name: "Anul Nou"},
+	{month: time.January, day: 2, name: "Anul Nou"},
+	{month: time.January, day: 6, name: "Boboteaza", since: 2024},
+	{month: time.January, day: 7, name: "Sfântul Ioan Botezătorul", since: 2024},
+	{month: time.January, day: 24, name: "Ziua Unirii Principatelor Române", since: 2017},
+	{month: time.May, day: 1, name: "Ziua Muncii"},
+	{month: time.June, day: 1, name: "Ziua Copilului", since: 2017},
+	{month: time.August, day: 15, name: "Adormirea Maicii Domnului", since: 2009},
+	{month: time.November, day: 30, name: "Sfântul Andrei", since: 2012},
+	{month: time.December, day: 1, name: "Ziua Națională a României"},
+	{month: time.December, day: 25, name: "Crăciunul"},
+	{month: time.December, day: 26, name: "Crăciunul"},
+}
+
+var easterHolidays = []easterHoliday{
+	{offset: -2, name: "Vinerea Mare", since: 2018},
+	{offset: 0, name: "Paștele"},
+	{offset: 1, name: "Paștele"},
+	{offset: 49, name: "Rusaliile", since: 2008},
+	{offset: 50, name: "Rusaliile", since: 2008},
+}
+
+// OrthodoxEaster returns the Orthodox Easter Sunday of the given year.
+func OrthodoxEaster(year int) Date {
+	// Meeus' algorithm gives the date in the Julian calendar.
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+
+	julianOffset := year/100 - year/400 - 2
+
+	return NewDate(year, month, day).AddDays(julianOffset)
+}
+
+// RomanianHolidays returns the Romanian public holidays of the given year, sorted by date.
+func RomanianHolidays(year int) []Holiday {
+	holidays := make([]Holiday, 0, len(fixedHolidays)+len(easterHolidays))
+
+	for _, h := range fixedHolidays {
+		if year >= h.since {
+			holidays = append(holidays, Holiday{Date: NewDate(year, int(h.month), h.day), Name: h.name})
+		}
+	}
+
+	easter := OrthodoxEaster(year)
+
+	for _, h := range easterHolidays {
+		if year >= h.since {
+			holidays = append(holidays, Holiday{Date: easter.AddDays(h.offset), Name: h.name})
+		}
+	}
+
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return holidays
+}
+
+func IsRomanianHoliday(d Date) bool {
+	return slices.ContainsFunc(RomanianHolidays(d.Year()), func(h Holiday) bool {
+		return h.Date.Equal(d)
+	})
+}
+
+// IsBusinessDay reports whether d is neither a weekend day nor a Romanian public holiday.
+func IsBusinessDay(d Date) bool {
+	if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+		return false
+	}
+
+	return !IsRomanianHoliday(d)
+}
+
+// AddBusinessDays moves d forward by the given number of business days.
+func AddBusinessDays(d Date, days int) Date {
+	for days > 0 {
+		d = d.AddDays(1)
+
+		if IsBusinessDay(d) {
+			days--
+		}
+	}
+
+	return d
+}
+
+// NextBusinessDay returns d when it is a business day, otherwise the first business day after it.
+func NextBusinessDay(d Date) Date {
+	for !IsBusinessDay(d) {
+		d = d.AddDays(1)
+	}
+
+	return d
+}
@@ -0,0 +1,50 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestOrthodoxEaster(t *testing.T) {
+	t.Parallel()
+
+	for year, want := range map[int]types.Date{
+		2019: types.NewDate(2019, 4, 28),
+		2021: types.NewDate(2021, 5, 2),
+		2023: types.NewDate(2023, 4, 16),
+		2024: types.NewDate(2024, 5, 5),
+		2025: types.NewDate(2025, 4, 20),
+		2026: types.NewDate(2026, 4, 12),
+	} {
+		require.Equal(t, want, types.OrthodoxEaster(year), year)
+	}
+}
+
+func TestRomanianHolidays(t *testing.T) {
+	t.Parallel()
+
+	holidays := types.RomanianHolidays(2024)
+	require.Len(t, holidays, 17)
+	require.Equal(t, types.Holiday{Date: types.NewDate(2024, 1, 1), Name: "Anul Nou"}, holidays[0])
+	require.Equal(t, types.Holiday{Date: types.NewDate(2024, 12, 26), Name: "Crăciunul"}, holidays[16])
+
+	require.Len(t, types.RomanianHolidays(2016), 12)
+	require.Len(t, types.RomanianHolidays(2010), 11)
+	require.Len(t, types.RomanianHolidays(2007), 8)
+
+	require.False(t, types.IsRomanianHoliday(types.NewDate(2008, 8, 15)))
+	require.True(t, types.IsRomanianHoliday(types.NewDate(2009, 8, 15)))
+	require.True(t, types.IsBusinessDay(types.NewDate(2011, 11, 30)))
+	require.False(t, types.IsBusinessDay(types.NewDate(2012, 11, 30)))
+
+	require.True(t, types.IsRomanianHoliday(types.NewDate(2024, 5, 3)))
+	require.True(t, types.IsRomanianHoliday(types.NewDate(2024, 6, 24)))
+	require.False(t, types.IsRomanianHoliday(types.NewDate(2023, 1, 6)))
+
+	require.False(t, types.IsBusinessDay(types.NewDate(2024, 12, 1)))
+	require.False(t, types.IsBusinessDay(types.NewDate(2024, 3, 2)))
+	require.True(t, types.IsBusinessDay(types.NewDate(2024, 3, 4)))
+	require.Equal(t, types.NewDate(2024, 12, 27), types.NextBusinessDay(types.NewDate(2024, 12, 25)))
+}
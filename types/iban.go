@@ -0,0 +1,146 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed ro_banks.csv
+var roBanksCSV string
+
+// ibanLengths are the IBAN lengths of the countries using IBAN.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+	"BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29,
+	"ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28,
+	"HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29,
+	"RO": 24, "RS": 22, "SA": 24, "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+var roBanks = struct {
+	sync.RWMutex
+	names map[string]string
+}{
+	names: parseBanks(roBanksCSV),
+}
+
+// NormalizeIBAN returns the IBAN in its electronic form, upper case and without spaces.
+func NormalizeIBAN(iban string) string {
+	return normalizeFiscalCode(iban)
+}
+
+// FormatIBAN returns the IBAN in its print form, in groups of four characters.
+func FormatIBAN(iban string) string {
+	iban = NormalizeIBAN(iban)
+
+	var sb strings.Builder
+
+	for i := 0; i < len(iban); i += 4 {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(iban[i:min(i+4, len(iban))])
+	}
+
+	return sb.String()
+}
+
+// ValidateIBAN checks the country, length, characters and checksum of an IBAN, in electronic or print form.
+func ValidateIBAN(iban string) error {
+	code := NormalizeIBAN(iban)
+
+	if len(code) < 4 {
+		return fmt.Errorf("iban %q is too short: %w", iban, ErrInvalidArgument)
+	}
+
+	length, ok := ibanLengths[code[:2]]
+	if !ok {
+		return fmt.Errorf("iban %q has an unknown country: %w", iban, ErrInvalidArgument)
+	}
+
+	if len(code) != length {
+		return fmt.Errorf("iban %q is not %d characters: %w", iban, length, ErrInvalidArgument)
+	}
+
+	remainder := 0
+
+	for _, r := range code[4:] + code[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return fmt.Errorf("iban %q has an invalid character %q: %w", iban, r, ErrInvalidArgument)
+		}
+	}
+
+	if remainder != 1 {
+		return fmt.Errorf("iban %q has a wrong checksum: %w", iban, ErrInvalidArgument)
+	}
+
+	return nil
+}
+
+// RomanianBankName returns the name of the bank a Romanian IBAN belongs to, from its bank code.
+func RomanianBankName(iban string) (string, bool) {
+	code := NormalizeIBAN(iban)
+	if len(code) < 8 || !strings.HasPrefix(code, "RO") {
+		return "", false
+	}
+
+	roBanks.RLock()
+	defer roBanks.RUnlock()
+
+	name, ok := roBanks.names[code[4:8]]
+
+	return name, ok
+}
+
+// RegisterRomanianBank adds or renames the bank with the given 4 letter code, for banks missing from the
+// embedded table.
+func RegisterRomanianBank(code, name string) {
+	roBanks.Lock()
+	defer roBanks.Unlock()
+
+	roBanks.names[strings.ToUpper(code)] = name
+}
+
+// FillBank sets Bank from the IBAN when it is empty and the IBAN belongs to a known Romanian bank. It reports
+// whether Bank was set.
+func (c *Client) FillBank() bool {
+	if c.Bank != "" {
+		return false
+	}
+
+	name, ok := RomanianBankName(c.IBAN)
+	if !ok {
+		return false
+	}
+
+	c.Bank = name
+
+	return true
+}
+
+func parseBanks(data string) map[string]string {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("types: parse banks: %v", err))
+	}
+
+	names := make(map[string]string, len(records))
+
+	for _, record := range records[1:] {
+		names[record[0]] = record[1]
+	}
+
+	return names
+}
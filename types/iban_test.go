@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestValidateIBAN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		iban  string
+		valid bool
+	}{
+		{iban: "RO21INGB0000999901234567", valid: true},
+		{iban: "ro65 btrl ronc rt01 2345 6789", valid: true},
+		{iban: "DE89370400440532013000", valid: true},
+		{iban: "GB82WEST12345698765432", valid: true},
+		{iban: "RO22INGB0000999901234567"},
+		{iban: "RO21INGB000099990123456"},
+		{iban: "ZZ21INGB0000999901234567"},
+		{iban: "RO21INGB00009999012345-7"},
+		{iban: "RO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.iban, func(t *testing.T) {
+			t.Parallel()
+
+			err := types.ValidateIBAN(tt.iban)
+
+			if tt.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, types.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestFormatIBAN(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "RO21 INGB 0000 9999 0123 4567", types.FormatIBAN("ro21ingb 0000999901234567"))
+	require.Equal(t, "NO93 8601 1117 947", types.FormatIBAN("NO9386011117947"))
+	require.Equal(t, "RO21INGB0000999901234567", types.NormalizeIBAN(" RO21 INGB 0000 9999 0123 4567 "))
+}
+
+func TestRomanianBankName(t *testing.T) {
+	t.Parallel()
+
+	name, ok := types.RomanianBankName("RO67 TREZ 7005 069X XX01 2345")
+	require.True(t, ok)
+	require.Equal(t, "Trezoreria Statului", name)
+
+	_, ok = types.RomanianBankName("DE89370400440532013000")
+	require.False(t, ok)
+
+	types.RegisterRomanianBank("zzzz", "Banca de Test")
+
+	name, ok = types.RomanianBankName("RO00ZZZZ0000000000000000")
+	require.True(t, ok)
+	require.Equal(t, "Banca de Test", name)
+}
+
+func TestClient_FillBank(t *testing.T) {
+	t.Parallel()
+
+	client := types.Client{IBAN: "RO21INGB0000999901234567"}
+	require.True(t, client.FillBank())
+	require.Equal(t, "ING Bank", client.Bank)
+
+	client = types.Client{IBAN: "RO65BTRLRONCRT0123456789", Bank: "BT"}
+	require.False(t, client.FillBank())
+	require.Equal(t, "BT", client.Bank)
+
+	client = types.Client{IBAN: "DE89370400440532013000"}
+	require.False(t, client.FillBank())
+	require.Empty(t, client.Bank)
+}
@@ -1,5 +1,10 @@
 package types
 
+import (
+	"errors"
+	"fmt"
+)
+
 type Company struct {
 	CIF            string `json:"cif,omitempty"`
 	Company        string `json:"company,omitempty"`
@@ -8,9 +13,9 @@ type Company struct {
 }
 
 type VATRate struct {
-	Name    string `json:"name,omitempty"`
-	Percent int    `json:"percent,omitempty"`
-	Default bool   `json:"default,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Percent Decimal `json:"percent,omitempty"`
+	Default bool    `json:"default,omitempty"`
 }
 
 type Client struct {
@@ -33,15 +38,38 @@ type Client struct {
 	Autocomplete Bool   `json:"autocomplete,omitempty"`
 }
 
+// Validate checks that the client can be identified, by its fiscal code or by its name, and that its fiscal
+// code and IBAN are well formed.
+func (c *Client) Validate() error {
+	var errs []error
+
+	switch {
+	case c.CIF == "" && c.Name == "":
+		errs = append(errs, fmt.Errorf("cif and name are empty: %w", ErrInvalidArgument))
+	case c.CIF != "":
+		if err := validateClientCode(c.CIF, c.Country); err != nil {
+			errs = append(errs, fmt.Errorf("cif: %w", err))
+		}
+	}
+
+	if c.IBAN != "" {
+		if err := ValidateIBAN(c.IBAN); err != nil {
+			errs = append(errs, fmt.Errorf("iban: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 type Stock struct {
-	WorkStation   string `json:"workStation,omitempty"`
-	Management    string `json:"management,omitempty"`
-	Quantity      int    `json:"quantity,omitempty"`
-	Price         string `json:"price,omitempty"`
-	Currency      string `json:"currency,omitempty"`
-	VATName       string `json:"vatName,omitempty"`
-	VATPercentage int    `json:"vatPercentage,omitempty"`
-	VATIncluded   bool   `json:"vatIncluded,omitempty"`
+	WorkStation   string  `json:"workStation,omitempty"`
+	Management    string  `json:"management,omitempty"`
+	Quantity      Decimal `json:"quantity,omitempty"`
+	Price         Decimal `json:"price,omitempty"`
+	Currency      string  `json:"currency,omitempty"`
+	VATName       string  `json:"vatName,omitempty"`
+	VATPercentage Decimal `json:"vatPercentage,omitempty"`
+	VATIncluded   bool    `json:"vatIncluded,omitempty"`
 }
 
 const (
@@ -68,21 +96,21 @@ type Product struct {
 	MeasuringUnit string      `json:"measuringUnit,omitempty"`
 	ProductType   ProductType `json:"productType,omitempty"`
 	Stock         []Stock     `json:"stock,omitempty"`
-	Price         string      `json:"price,omitempty"`
+	Price         Decimal     `json:"price,omitempty"`
 	Currency      string      `json:"currency,omitempty"`
 	VATName       string      `json:"vatName,omitempty"`
-	VATPercentage Int         `json:"vatPercentage,omitempty"`
+	VATPercentage Decimal     `json:"vatPercentage,omitempty"`
 	VATIncluded   Bool        `json:"vatIncluded,omitempty"`
 	Active        Bool        `json:"active,omitempty"`
 	Image         string      `json:"image,omitempty"`
 }
 
 type Series struct {
-	Type    string `json:"type,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Start   string `json:"start,omitempty"`
-	Next    string `json:"next,omitempty"`
-	Default bool   `json:"default,omitempty"`
+	Type    DocumentType `json:"type,omitempty"`
+	Name    string       `json:"name,omitempty"`
+	Start   string       `json:"start,omitempty"`
+	Next    string       `json:"next,omitempty"`
+	Default bool         `json:"default,omitempty"`
 }
 
 type Language struct {
@@ -91,7 +119,7 @@ type Language struct {
 }
 
 type Management struct {
-	Management     string `json:"management,omitempty"`
-	WorkStation    string `json:"workStation,omitempty"`
-	ManagementType string `json:"managementType,omitempty"`
+	Management     string         `json:"management,omitempty"`
+	WorkStation    string         `json:"workStation,omitempty"`
+	ManagementType ManagementType `json:"managementType,omitempty"`
 }
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+type PaymentTermsKind string
+
+const (
+	// NetPaymentTerms are due Days calendar days after the issue date.
+	NetPaymentTerms PaymentTermsKind = "net"
+	// EndOfMonthPaymentTerms are due Days calendar days after the end of the issue month.
+	EndOfMonthPaymentTerms PaymentTermsKind = "endOfMonth"
+	// DayOfNextMonthPaymentTerms are due on Day of the month after the issue month, or on its last day when the
+	// month is shorter.
+	DayOfNextMonthPaymentTerms PaymentTermsKind = "dayOfNextMonth"
+	// BusinessDaysPaymentTerms are due Days business days after the issue date, skipping weekends and Romanian
+	// public holidays.
+	BusinessDaysPaymentTerms PaymentTermsKind = "businessDays"
+)
+
+// PaymentTerms describe when a client pays, so the due date can be derived from the issue date. When
+// OnBusinessDay is set, a due date falling on a weekend or a public holiday moves to the next business day.
+type PaymentTerms struct {
+	Kind          PaymentTermsKind `json:"kind"`
+	Days          int              `json:"days,omitempty"`
+	Day           int              `json:"day,omitempty"`
+	OnBusinessDay bool             `json:"onBusinessDay,omitempty"`
+}
+
+func NetDays(days int) PaymentTerms {
+	return PaymentTerms{Kind: NetPaymentTerms, Days: days}
+}
+
+func EndOfMonthPlus(days int) PaymentTerms {
+	return PaymentTerms{Kind: EndOfMonthPaymentTerms, Days: days}
+}
+
+func DayOfNextMonth(day int) PaymentTerms {
+	return PaymentTerms{Kind: DayOfNextMonthPaymentTerms, Day: day}
+}
+
+func BusinessDays(days int) PaymentTerms {
+	return PaymentTerms{Kind: BusinessDaysPaymentTerms, Days: days}
+}
+
+func (t PaymentTerms) Validate() error {
+	var errs []error
+
+	if t.Days < 0 {
+		errs = append(errs, fmt.Errorf("days %d is negative: %w", t.Days, ErrInvalidArgument))
+	}
+
+	switch t.Kind {
+	case NetPaymentTerms, EndOfMonthPaymentTerms, BusinessDaysPaymentTerms:
+	case DayOfNextMonthPaymentTerms:
+		if t.Day < 1 || t.Day > 31 {
+			errs = append(errs, fmt.Errorf("day %d is out of range: %w", t.Day, ErrInvalidArgument))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("kind %q is unknown: %w", t.Kind, ErrInvalidArgument))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DueDate returns the due date of a document issued on issueDate.
+func (t PaymentTerms) DueDate(issueDate Date) (Date, error) {
+	if err := t.Validate(); err != nil {
+		return Date{}, err
+	}
+
+	var due Date
+
+	switch t.Kind {
+	case NetPaymentTerms:
+		due = issueDate.AddDays(t.Days)
+	case EndOfMonthPaymentTerms:
+		due = issueDate.EndOfMonth().AddDays(t.Days)
+	case DayOfNextMonthPaymentTerms:
+		next := issueDate.StartOfMonth().AddMonths(1)
+		due = NewDate(next.Year(), int(next.Month()), min(t.Day, next.EndOfMonth().Day()))
+	case BusinessDaysPaymentTerms:
+		due = AddBusinessDays(issueDate, t.Days)
+	}
+
+	if t.OnBusinessDay {
+		due = NextBusinessDay(due)
+	}
+
+	return due, nil
+}
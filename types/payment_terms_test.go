@@ -0,0 +1,91 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestPaymentTerms_DueDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		terms     types.PaymentTerms
+		issueDate types.Date
+		want      types.Date
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "net days",
+			terms:     types.NetDays(30),
+			issueDate: types.NewDate(2024, 1, 31),
+			want:      types.NewDate(2024, 3, 1),
+		},
+		{
+			name:      "end of month plus days",
+			terms:     types.EndOfMonthPlus(15),
+			issueDate: types.NewDate(2024, 1, 10),
+			want:      types.NewDate(2024, 2, 15),
+		},
+		{
+			name:      "day of next month clamped",
+			terms:     types.DayOfNextMonth(31),
+			issueDate: types.NewDate(2024, 1, 31),
+			want:      types.NewDate(2024, 2, 29),
+		},
+		{
+			name:      "day of next month over new year",
+			terms:     types.DayOfNextMonth(10),
+			issueDate: types.NewDate(2024, 12, 20),
+			want:      types.NewDate(2025, 1, 10),
+		},
+		{
+			name:      "business days over easter",
+			terms:     types.BusinessDays(5),
+			issueDate: types.NewDate(2024, 4, 29),
+			want:      types.NewDate(2024, 5, 9),
+		},
+		{
+			name:      "moved to business day",
+			terms:     types.PaymentTerms{Kind: types.NetPaymentTerms, Days: 30, OnBusinessDay: true},
+			issueDate: types.NewDate(2024, 11, 1),
+			want:      types.NewDate(2024, 12, 2),
+		},
+		{
+			name:      "invalid day",
+			terms:     types.DayOfNextMonth(0),
+			issueDate: types.NewDate(2024, 1, 1),
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument)
+			},
+		},
+		{
+			name:      "unknown kind",
+			terms:     types.PaymentTerms{Days: -1},
+			issueDate: types.NewDate(2024, 1, 1),
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorContains(t, err, "kind") && assert.ErrorContains(t, err, "negative")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.terms.DueDate(tt.issueDate)
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
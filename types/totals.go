@@ -0,0 +1,317 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	NetRoundingStep      = "net"
+	VATRoundingStep      = "vat"
+	GrossRoundingStep    = "gross"
+	DiscountRoundingStep = "discount"
+)
+
+// guardDigits are the extra decimal places kept by divisions before the final rounding.
+const guardDigits = 10
+
+var hundred = NewDecimalFromInt(100)
+
+type Amounts struct {
+	Net   Decimal
+	VAT   Decimal
+	Gross Decimal
+}
+
+func (a Amounts) add(other Amounts) Amounts {
+	return Amounts{
+		Net:   a.Net.Add(other.Net),
+		VAT:   a.VAT.Add(other.VAT),
+		Gross: a.Gross.Add(other.Gross),
+	}
+}
+
+func (a Amounts) round(places int) Amounts {
+	return Amounts{
+		Net:   a.Net.Round(places),
+		VAT:   a.VAT.Round(places),
+		Gross: a.Gross.Round(places),
+	}
+}
+
+func (a Amounts) neg() Amounts {
+	return Amounts{
+		Net:   a.Net.Neg(),
+		VAT:   a.VAT.Neg(),
+		Gross: a.Gross.Neg(),
+	}
+}
+
+type VATBreakdown struct {
+	Amounts
+
+	VATName       string
+	VATPercentage Decimal
+}
+
+// RoundingStep records one rounding made while computing the totals. Row is the index of the document row
+// the rounding belongs to.
+type RoundingStep struct {
+	Row     int
+	Name    string
+	Step    string
+	Exact   Decimal
+	Rounded Decimal
+}
+
+type Totals struct {
+	Amounts
+
+	Breakdown []VATBreakdown
+	Trace     []RoundingStep
+}
+
+type TotalMismatchError struct {
+	Expected Decimal
+	Actual   Decimal
+}
+
+func (e *TotalMismatchError) Error() string {
+	return fmt.Sprintf("total mismatch: calculated %s, document has %s", e.Expected, e.Actual)
+}
+
+// CheckDocument compares the calculated gross total with the total returned by Oblio and returns a
+// *TotalMismatchError when they differ.
+func (t *Totals) CheckDocument(doc Document) error {
+	if t.Gross.Equal(doc.Total) {
+		return nil
+	}
+
+	return &TotalMismatchError{
+		Expected: t.Gross,
+		Actual:   doc.Total,
+	}
+}
+
+type lineTotals struct {
+	Amounts
+
+	item *LineItem
+}
+
+// base returns the amount the line price is expressed in, gross for VAT included prices and net otherwise.
+func (l lineTotals) base() Decimal {
+	if l.item.PriceIncludesVAT() {
+		return l.Gross
+	}
+
+	return l.Net
+}
+
+type totalsCalculator struct {
+	precision int
+	totals    *Totals
+}
+
+// CalculateTotals computes the net, VAT and gross amounts of the document rows per VAT rate. Every line is
+// rounded to precision, SimplePrecision when zero, the way Oblio does: VAT included prices are split from the
+// rounded gross amount, otherwise the VAT is computed on the rounded net amount. A discount row is split
+// across the VAT rates of the lines it refers to; a flat discount over several lines is split proportionally
+// to their gross amounts for VAT included prices and to their net amounts otherwise. Invalid rows are
+// reported before anything is computed.
+func CalculateTotals(rows []DocumentRow, precision int) (*Totals, error) {
+	if precision == 0 {
+		precision = SimplePrecision
+	}
+
+	c := &totalsCalculator{
+		precision: precision,
+		totals: &Totals{
+			Amounts: Amounts{Net: "0", VAT: "0", Gross: "0"},
+		},
+	}
+
+	if err := validateTotalsRows(rows); err != nil {
+		return nil, err
+	}
+
+	var lines []lineTotals
+
+	for i, row := range rows {
+		switch r := row.(type) {
+		case *LineItem:
+			amounts := c.line(i, r)
+			lines = append(lines, lineTotals{Amounts: amounts, item: r})
+			c.add(r, amounts)
+		case *Discount:
+			refs := c.refs(r, lines)
+			if len(refs) == 0 {
+				return nil, fmt.Errorf(
+					"rows[%d]: discount refers to unknown line %q: %w", i, r.RefItem, ErrInvalidArgument)
+			}
+
+			for _, ref := range c.discount(i, r, refs) {
+				c.add(ref.item, ref.Amounts)
+			}
+		default:
+			return nil, fmt.Errorf("rows[%d]: unsupported row %T: %w", i, row, ErrInvalidArgument)
+		}
+	}
+
+	c.totals.Amounts = c.totals.Amounts.round(precision)
+
+	for i := range c.totals.Breakdown {
+		c.totals.Breakdown[i].Amounts = c.totals.Breakdown[i].Amounts.round(precision)
+	}
+
+	return c.totals, nil
+}
+
+func validateTotalsRows(rows []DocumentRow) error {
+	var errs []error
+
+	for i, row := range rows {
+		var validator interface{ Validate() error }
+
+		switch r := row.(type) {
+		case *LineItem:
+			if r != nil {
+				validator = r
+			}
+		case *Discount:
+			if r != nil {
+				validator = r
+			}
+		default:
+			continue
+		}
+
+		if validator == nil {
+			errs = append(errs, fmt.Errorf("rows[%d] is nil: %w", i, ErrInvalidArgument))
+		} else if err := validator.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rows[%d]: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *totalsCalculator) line(row int, item *LineItem) Amounts {
+	quantity := item.Quantity
+	if quantity.IsEmpty() {
+		quantity = "1"
+	}
+
+	value := item.Price.Mul(quantity)
+
+	switch item.DiscountType {
+	case PercentageDiscountType:
+		value = value.Sub(value.Mul(item.Discount).quo(hundred, c.precision+guardDigits))
+	case FlatDiscountType:
+		value = value.Sub(item.Discount)
+	}
+
+	return c.split(row, item, value)
+}
+
+// split rounds value, expressed the way the line prices are, and derives the other amounts from it.
+func (c *totalsCalculator) split(row int, item *LineItem, value Decimal) Amounts {
+	vat := item.VATPercentage
+
+	if item.PriceIncludesVAT() {
+		gross := c.round(row, item.Name, GrossRoundingStep, value)
+		exact := gross.Mul(hundred).quo(hundred.Add(vat), c.precision+guardDigits)
+		net := c.round(row, item.Name, NetRoundingStep, exact)
+
+		return Amounts{Net: net, VAT: gross.Sub(net), Gross: gross}
+	}
+
+	net := c.round(row, item.Name, NetRoundingStep, value)
+	vatAmount := c.round(row, item.Name, VATRoundingStep, net.Mul(vat).quo(hundred, c.precision+guardDigits))
+
+	return Amounts{Net: net, VAT: vatAmount, Gross: net.Add(vatAmount)}
+}
+
+func (c *totalsCalculator) refs(discount *Discount, lines []lineTotals) []lineTotals {
+	if discount.DiscountAllAbove {
+		return lines
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i].item.Name == discount.RefItem {
+			return lines[i : i+1]
+		}
+	}
+
+	return nil
+}
+
+func (c *totalsCalculator) discount(row int, discount *Discount, refs []lineTotals) []lineTotals {
+	out := make([]lineTotals, 0, len(refs))
+
+	if discount.DiscountType == PercentageDiscountType {
+		for _, ref := range refs {
+			exact := ref.base().Mul(discount.Discount).quo(hundred, c.precision+guardDigits)
+			value := c.round(row, discount.Name, DiscountRoundingStep, exact)
+			out = append(out, lineTotals{Amounts: c.split(row, ref.item, value).neg(), item: ref.item})
+		}
+
+		return out
+	}
+
+	total := Decimal("0")
+
+	for _, ref := range refs {
+		total = total.Add(ref.base())
+	}
+
+	remaining := discount.Discount
+
+	for i, ref := range refs {
+		share := remaining
+
+		if i < len(refs)-1 && !total.IsZero() {
+			share = c.round(row, discount.Name, DiscountRoundingStep,
+				discount.Discount.Mul(ref.base()).quo(total, c.precision+guardDigits))
+			remaining = remaining.Sub(share)
+		}
+
+		out = append(out, lineTotals{Amounts: c.split(row, ref.item, share).neg(), item: ref.item})
+	}
+
+	return out
+}
+
+func (c *totalsCalculator) add(item *LineItem, amounts Amounts) {
+	c.totals.Amounts = c.totals.Amounts.add(amounts)
+
+	for i := range c.totals.Breakdown {
+		b := &c.totals.Breakdown[i]
+
+		if b.VATName == item.VATName && b.VATPercentage.Equal(item.VATPercentage) {
+			b.Amounts = b.Amounts.add(amounts)
+
+			return
+		}
+	}
+
+	c.totals.Breakdown = append(c.totals.Breakdown, VATBreakdown{
+		Amounts:       Amounts{Net: "0", VAT: "0", Gross: "0"}.add(amounts),
+		VATName:       item.VATName,
+		VATPercentage: item.VATPercentage,
+	})
+}
+
+func (c *totalsCalculator) round(row int, name, step string, exact Decimal) Decimal {
+	rounded := exact.Round(c.precision)
+
+	c.totals.Trace = append(c.totals.Trace, RoundingStep{
+		Row:     row,
+		Name:    name,
+		Step:    step,
+		Exact:   exact,
+		Rounded: rounded,
+	})
+
+	return rounded
+}
@@ -0,0 +1,165 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestCalculateTotals(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		totals    types.Amounts
+		breakdown []types.VATBreakdown
+	}
+
+	tests := []struct {
+		name      string
+		rows      []types.DocumentRow
+		precision int
+		want      want
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "vat excluded",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Ore", Price: "33.33", Quantity: "2.5", VATName: "Normala", VATPercentage: "19", VATIncluded: types.NewBool(false)},
+			},
+			want: want{
+				totals: types.Amounts{Net: "83.33", VAT: "15.83", Gross: "99.16"},
+				breakdown: []types.VATBreakdown{
+					{
+						Amounts:       types.Amounts{Net: "83.33", VAT: "15.83", Gross: "99.16"},
+						VATName:       "Normala",
+						VATPercentage: "19",
+					},
+				},
+			},
+		},
+		{
+			name: "vat included with several rates",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Birou", Price: "119", VATName: "Normala", VATPercentage: "19", VATIncluded: types.NewBool(true)},
+				&types.LineItem{Name: "Carte", Price: "109", VATName: "Redusa", VATPercentage: "9", VATIncluded: types.NewBool(true)},
+			},
+			want: want{
+				totals: types.Amounts{Net: "200.00", VAT: "28.00", Gross: "228.00"},
+				breakdown: []types.VATBreakdown{
+					{Amounts: types.Amounts{Net: "100.00", VAT: "19.00", Gross: "119.00"}, VATName: "Normala", VATPercentage: "19"},
+					{Amounts: types.Amounts{Net: "100.00", VAT: "9.00", Gross: "109.00"}, VATName: "Redusa", VATPercentage: "9"},
+				},
+			},
+		},
+		{
+			name: "percentage and flat discounts",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Birou", Price: "100", Quantity: "2", VATName: "Normala", VATPercentage: "19", VATIncluded: types.NewBool(false)},
+				&types.Discount{Name: "Reducere", RefItem: "Birou", Discount: "10", DiscountType: types.PercentageDiscountType},
+				&types.LineItem{Name: "Carte", Price: "50", VATName: "Redusa", VATPercentage: "9", VATIncluded: types.NewBool(false)},
+				&types.Discount{Name: "Fidelitate", Discount: "10", DiscountType: types.FlatDiscountType, DiscountAllAbove: true},
+			},
+			want: want{
+				totals: types.Amounts{Net: "220.00", VAT: "37.00", Gross: "257.00"},
+				breakdown: []types.VATBreakdown{
+					{Amounts: types.Amounts{Net: "172.00", VAT: "32.68", Gross: "204.68"}, VATName: "Normala", VATPercentage: "19"},
+					{Amounts: types.Amounts{Net: "48.00", VAT: "4.32", Gross: "52.32"}, VATName: "Redusa", VATPercentage: "9"},
+				},
+			},
+		},
+		{
+			name: "flat discount over vat included and excluded lines",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Birou", Price: "100", VATName: "Normala", VATPercentage: "19", VATIncluded: types.NewBool(false)},
+				&types.LineItem{Name: "Carte", Price: "119", VATName: "Normala", VATPercentage: "19"},
+				&types.Discount{Name: "Fidelitate", Discount: "20", DiscountType: types.FlatDiscountType, DiscountAllAbove: true},
+			},
+			want: want{
+				// Birou gets 9.13 off its net price and Carte 10.87 off its gross price.
+				totals: types.Amounts{Net: "181.74", VAT: "34.53", Gross: "216.27"},
+				breakdown: []types.VATBreakdown{
+					{Amounts: types.Amounts{Net: "181.74", VAT: "34.53", Gross: "216.27"}, VATName: "Normala", VATPercentage: "19"},
+				},
+			},
+		},
+		{
+			name: "double precision",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Curent", Price: "0.7512", Quantity: "3", VATName: "Normala", VATPercentage: "19", VATIncluded: types.NewBool(false)},
+			},
+			precision: types.DoublePrecision,
+			want: want{
+				totals: types.Amounts{Net: "2.2536", VAT: "0.4282", Gross: "2.6818"},
+				breakdown: []types.VATBreakdown{
+					{Amounts: types.Amounts{Net: "2.2536", VAT: "0.4282", Gross: "2.6818"}, VATName: "Normala", VATPercentage: "19"},
+				},
+			},
+		},
+		{
+			name: "nil line",
+			rows: []types.DocumentRow{(*types.LineItem)(nil)},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "rows[0] is nil")
+			},
+		},
+		{
+			name: "invalid vat percentage",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Birou", Price: "100", VATPercentage: "-100", VATIncluded: types.NewBool(true)},
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "vatPercentage")
+			},
+		},
+		{
+			name: "unknown reference",
+			rows: []types.DocumentRow{
+				&types.LineItem{Name: "Birou", Price: "100"},
+				&types.Discount{Name: "Reducere", RefItem: "Scaun", Discount: "10", DiscountType: types.PercentageDiscountType},
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := types.CalculateTotals(tt.rows, tt.precision)
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want.totals, got.Amounts)
+			require.Equal(t, tt.want.breakdown, got.Breakdown)
+			require.NotEmpty(t, got.Trace)
+		})
+	}
+}
+
+func TestTotals_CheckDocument(t *testing.T) {
+	t.Parallel()
+
+	totals, err := types.CalculateTotals([]types.DocumentRow{
+		&types.LineItem{Name: "Birou", Price: "100", VATPercentage: "19", VATIncluded: types.NewBool(false)},
+	}, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, totals.CheckDocument(types.Document{Total: "119.0000"}))
+
+	err = totals.CheckDocument(types.Document{Total: "119.01"})
+
+	var mismatch *types.TotalMismatchError
+
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, types.Decimal("119.00"), mismatch.Expected)
+	require.Equal(t, types.Decimal("119.01"), mismatch.Actual)
+}
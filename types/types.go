@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -24,10 +26,82 @@ var (
 	_ query.Encoder = (*Date)(nil)
 )
 
+// Date is a calendar date, independent of time zones. It is kept as midnight UTC of that date, so two Dates for
+// the same day are always equal.
 type Date time.Time
 
+var (
+	businessLocation atomic.Pointer[time.Location]
+
+	// bucharest is loaded on first use from the time zone database of the system or, when the application
+	// imports it, from time/tzdata. It is nil when neither has the zone.
+	bucharest = sync.OnceValue(func() *time.Location {
+		loc, err := time.LoadLocation("Europe/Bucharest")
+		if err != nil {
+			return nil
+		}
+
+		return loc
+	})
+)
+
+// SetBusinessLocation sets the time zone Today and DateOf use to tell the current day. It defaults to
+// Europe/Bucharest.
+func SetBusinessLocation(loc *time.Location) {
+	businessLocation.Store(loc)
+}
+
+// BusinessLocation returns the location set with SetBusinessLocation, Europe/Bucharest by default. Without a time
+// zone database holding Europe/Bucharest, it returns the fixed EET or EEST offset in effect now.
+func BusinessLocation() *time.Location {
+	return businessLocationAt(time.Now())
+}
+
+func businessLocationAt(t time.Time) *time.Location {
+	if loc := businessLocation.Load(); loc != nil {
+		return loc
+	}
+
+	if loc := bucharest(); loc != nil {
+		return loc
+	}
+
+	return romanianOffsetAt(t)
+}
+
+var (
+	eet  = time.FixedZone("EET", 2*60*60)
+	eest = time.FixedZone("EEST", 3*60*60)
+)
+
+// romanianOffsetAt returns the offset of Romanian time at t. Summer time runs from the last Sunday of March to
+// the last Sunday of October, changing at 01:00 UTC as everywhere in the EU.
+func romanianOffsetAt(t time.Time) *time.Location {
+	t = t.UTC()
+
+	lastSunday := func(month time.Month) time.Time {
+		end := time.Date(t.Year(), month+1, 0, 1, 0, 0, 0, time.UTC)
+
+		return end.AddDate(0, 0, -int(end.Weekday()))
+	}
+
+	if !t.Before(lastSunday(time.March)) && t.Before(lastSunday(time.October)) {
+		return eest
+	}
+
+	return eet
+}
+
+// Today returns the current date in the business location.
 func Today() Date {
-	return Date(time.Now())
+	return DateOf(time.Now())
+}
+
+// DateOf returns the date t falls on in the business location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.In(businessLocationAt(t)).Date()
+
+	return NewDate(year, int(month), day)
 }
 
 func NewDate(year, month, day int) Date {
@@ -55,16 +129,18 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON marshals the zero Date as an empty string.
 func (d Date) MarshalJSON() ([]byte, error) {
-	s := time.Time(d).Format(dateLayout)
-
-	return json.Marshal(s)
+	return json.Marshal(d.String())
 }
 
+// EncodeValues skips the zero Date, so unset filters are not sent.
 func (d Date) EncodeValues(key string, v *url.Values) error {
-	s := time.Time(d).Format(dateLayout)
+	if d.IsZero() {
+		return nil
+	}
 
-	v.Set(key, s)
+	v.Set(key, d.String())
 
 	return nil
 }
@@ -77,8 +153,80 @@ func (d Date) AsTime() time.Time {
 	return time.Time(d)
 }
 
+// String formats d as "2006-01-02", or returns an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	return d.AsTime().Format(dateLayout)
+}
+
+func (d Date) Year() int {
+	return d.AsTime().Year()
+}
+
+func (d Date) Month() time.Month {
+	return d.AsTime().Month()
+}
+
+func (d Date) Day() int {
+	return d.AsTime().Day()
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.AsTime().Weekday()
+}
+
+func (d Date) AddDays(days int) Date {
+	return Date(d.AsTime().AddDate(0, 0, days))
+}
+
+// AddMonths adds months to d, keeping the day within the target month: January 31 plus one month is the last
+// day of February.
+func (d Date) AddMonths(months int) Date {
+	first := time.Date(d.Year(), d.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+
+	return NewDate(first.Year(), int(first.Month()), min(d.Day(), Date(first).EndOfMonth().Day()))
+}
+
+func (d Date) StartOfMonth() Date {
+	return NewDate(d.Year(), int(d.Month()), 1)
+}
+
+func (d Date) EndOfMonth() Date {
+	return Date(time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC))
+}
+
+// Compare returns -1 when d is before other, 0 when they are the same day and +1 when d is after other.
+func (d Date) Compare(other Date) int {
+	return d.AsTime().Compare(other.AsTime())
+}
+
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+func (d Date) Equal(other Date) bool {
+	return d.Compare(other) == 0
+}
+
+// DaysUntil returns the number of days from d to other, negative when other is before d.
+func (d Date) DaysUntil(other Date) int {
+	return int(other.AsTime().Sub(d.AsTime()).Hours() / 24)
+}
+
 type Bool bool
 
+// NewBool returns a pointer to b, for optional fields such as LineItem.VATIncluded.
+func NewBool(b bool) *Bool {
+	return (*Bool)(&b)
+}
+
 var (
 	_ Marshaler     = (*Bool)(nil)
 	_ query.Encoder = (*Bool)(nil)
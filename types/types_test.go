@@ -35,6 +35,10 @@ func TestDate_MarshalJSON(t *testing.T) {
 			},
 			want: `{"date":"2024-01-01"}`,
 		},
+		{
+			name: "zero",
+			want: `{"date":""}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,11 +118,72 @@ func TestDate_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDateOf(t *testing.T) {
+	t.Parallel()
+
+	// Just after midnight in Bucharest, still the previous day in UTC.
+	instant := time.Date(2024, 1, 15, 22, 30, 0, 0, time.UTC)
+
+	require.Equal(t, types.NewDate(2024, 1, 16), types.DateOf(instant))
+	require.Equal(t, types.NewDate(2024, 1, 16), types.DateOf(instant.In(time.FixedZone("EST", -5*3600))))
+}
+
+func TestRomanianOffsetAt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		instant time.Time
+		want    string
+	}{
+		{instant: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), want: "EET"},
+		{instant: time.Date(2024, 3, 31, 0, 59, 0, 0, time.UTC), want: "EET"},
+		{instant: time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC), want: "EEST"},
+		{instant: time.Date(2024, 7, 1, 21, 30, 0, 0, time.UTC), want: "EEST"},
+		{instant: time.Date(2024, 10, 27, 0, 59, 0, 0, time.UTC), want: "EEST"},
+		{instant: time.Date(2024, 10, 27, 1, 0, 0, 0, time.UTC), want: "EET"},
+	}
+
+	for _, tt := range tests {
+		got, _ := tt.instant.In(types.RomanianOffsetAt(tt.instant)).Zone()
+		require.Equal(t, tt.want, got, tt.instant)
+
+		if loc, err := time.LoadLocation("Europe/Bucharest"); err == nil {
+			want, _ := tt.instant.In(loc).Zone()
+			require.Equal(t, want, got, tt.instant)
+		}
+	}
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	t.Parallel()
+
+	d := types.NewDate(2024, 1, 31)
+
+	require.Equal(t, types.NewDate(2024, 2, 10), d.AddDays(10))
+	require.Equal(t, types.NewDate(2023, 12, 31), d.AddDays(-31))
+	require.Equal(t, types.NewDate(2024, 2, 29), d.AddMonths(1))
+	require.Equal(t, types.NewDate(2023, 2, 28), d.AddMonths(-11))
+	require.Equal(t, types.NewDate(2024, 4, 30), d.AddMonths(3))
+	require.Equal(t, types.NewDate(2024, 2, 29), types.NewDate(2024, 2, 3).EndOfMonth())
+	require.Equal(t, types.NewDate(2024, 12, 31), types.NewDate(2024, 12, 3).EndOfMonth())
+	require.Equal(t, types.NewDate(2024, 2, 1), types.NewDate(2024, 2, 17).StartOfMonth())
+	require.Equal(t, 30, d.DaysUntil(types.NewDate(2024, 3, 1)))
+	require.Equal(t, time.Wednesday, d.Weekday())
+	require.Equal(t, "2024-01-31", d.String())
+	require.Empty(t, types.Date{}.String())
+
+	require.True(t, d.Before(d.AddDays(1)))
+	require.True(t, d.After(d.AddDays(-1)))
+	require.True(t, d.Equal(types.NewDate(2024, 1, 31)))
+	require.Equal(t, 0, d.Compare(types.NewDate(2024, 1, 31)))
+}
+
 func TestDate_EncodeValues(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
-		Date types.Date `json:"date" url:"date,omitempty"`
+		Date  types.Date `json:"date" url:"date,omitempty"`
+		Until types.Date `json:"until" url:"until"`
 	}
 
 	tests := []struct {
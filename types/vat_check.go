@@ -0,0 +1,64 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrVATMismatch = errors.New("vat registration mismatch")
+
+// VATConsultation records a check of an EU VAT number in VIES. Store it alongside intra-community invoices as
+// proof that the number of the buyer was valid when they were issued.
+type VATConsultation struct {
+	CountryCode string    `json:"countryCode"`
+	VATNumber   string    `json:"vatNumber"`
+	Valid       bool      `json:"valid"`
+	Name        string    `json:"name,omitempty"`
+	Address     string    `json:"address,omitempty"`
+	RequestDate time.Time `json:"requestDate"`
+	// RequestIdentifier is the consultation number VIES gives when the requester VAT number is sent.
+	RequestIdentifier string `json:"requestIdentifier,omitempty"`
+}
+
+// VATChecker checks an EU VAT number prefixed by its country code, e.g. "DE123456789".
+type VATChecker interface {
+	CheckVAT(ctx context.Context, vatNumber string) (VATConsultation, error)
+}
+
+// Compare reports, wrapped in ErrVATMismatch, an invalid number and a client name or city that differs from
+// the registered ones. Case, diacritics and punctuation are ignored and a name matches when either contains the
+// other, e.g. "Acme" and "ACME GmbH". Some countries do not disclose the name and address, which are then not
+// compared.
+func (c VATConsultation) Compare(client Client) error {
+	if !c.Valid {
+		return fmt.Errorf("vat number %s%s is not valid: %w", c.CountryCode, c.VATNumber, ErrVATMismatch)
+	}
+
+	var errs []error
+
+	if name, registered := normalizeRegistryText(client.Name), normalizeRegistryText(c.Name); name != "" &&
+		registered != "" && !strings.Contains(name, registered) && !strings.Contains(registered, name) {
+		errs = append(errs, fmt.Errorf("name %q is registered as %q: %w", client.Name, c.Name, ErrVATMismatch))
+	}
+
+	if city, address := normalizeRegistryText(client.City), normalizeRegistryText(c.Address); city != "" &&
+		address != "" && !strings.Contains(address, city) {
+		errs = append(errs, fmt.Errorf("city %q is not in the registered address %q: %w", client.City, c.Address,
+			ErrVATMismatch))
+	}
+
+	return errors.Join(errs...)
+}
+
+// normalizeRegistryText keeps the upper case letters and digits of s separated by single spaces. The "---"
+// VIES returns for undisclosed data becomes empty.
+func normalizeRegistryText(s string) string {
+	s = strings.ToUpper(diacritics.Replace(s))
+
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r < 0x80
+	}), " ")
+}
@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestVATConsultation_Compare(t *testing.T) {
+	t.Parallel()
+
+	consultation := types.VATConsultation{
+		CountryCode: "DE",
+		VATNumber:   "123456789",
+		Valid:       true,
+		Name:        "MÜLLER & SÖHNE GMBH",
+		Address:     "HAUPTSTR. 1\n80331 MÜNCHEN",
+	}
+
+	require.NoError(t, consultation.Compare(types.Client{Name: "Müller & Söhne", City: "München"}))
+	require.NoError(t, consultation.Compare(types.Client{Name: "Müller"}))
+	require.NoError(t, types.VATConsultation{Valid: true}.Compare(types.Client{Name: "Acme", City: "Berlin"}))
+
+	err := consultation.Compare(types.Client{Name: "Acme GmbH", City: "Berlin"})
+	require.ErrorIs(t, err, types.ErrVATMismatch)
+	require.ErrorContains(t, err, `name "Acme GmbH"`)
+	require.ErrorContains(t, err, `city "Berlin"`)
+
+	consultation.Valid = false
+	require.ErrorContains(t, consultation.Compare(types.Client{}), "DE123456789 is not valid")
+}
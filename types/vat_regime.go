@@ -0,0 +1,267 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var countryCodeRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// The VAT names of the default Oblio nomenclature used by the VAT treatments.
+const (
+	StandardVATName            = "Normala"
+	ExemptWithCreditVATName    = "SDD"
+	ExemptWithoutCreditVATName = "SFDD"
+	ReverseChargeVATName       = "Taxare inversa"
+	NotTaxableVATName          = "Neimpozabil"
+)
+
+// OSSVATName is the VAT name of OSS lines whose country is missing from VATRules.OSSVATNames. The company VAT
+// nomenclature must hold it with the rate of each destination country for Preflight to accept the lines.
+const OSSVATName = "OSS"
+
+// DefaultStandardVATRate is the Romanian standard VAT rate since August 2025.
+const DefaultStandardVATRate = 21
+
+type VATRegime string
+
+const (
+	DomesticVATRegime              VATRegime = "domestic"
+	DomesticReverseChargeVATRegime VATRegime = "domesticReverseCharge"
+	IntraCommunityGoodsVATRegime   VATRegime = "intraCommunityGoods"
+	IntraCommunityServiceVATRegime VATRegime = "intraCommunityService"
+	ExportVATRegime                VATRegime = "export"
+	NotTaxableServiceVATRegime     VATRegime = "notTaxableService"
+	OSSVATRegime                   VATRegime = "oss"
+	SmallEnterpriseVATRegime       VATRegime = "smallEnterprise"
+)
+
+// The legal mentions Romanian invoices must carry for the VAT treatments without Romanian VAT.
+const (
+	DomesticReverseChargeMention = "Taxare inversă conform art. 331 din Codul fiscal."
+	IntraCommunityGoodsMention   = "Scutit cu drept de deducere conform art. 294 alin. (2) lit. a) din Codul fiscal - " +
+		"livrare intracomunitară de bunuri."
+	IntraCommunityServiceMention = "Taxare inversă conform art. 278 alin. (2) din Codul fiscal și art. 196 din " +
+		"Directiva 2006/112/CE."
+	ExportMention            = "Scutit cu drept de deducere conform art. 294 alin. (1) lit. a) din Codul fiscal - export."
+	NotTaxableServiceMention = "Operațiune neimpozabilă în România conform art. 278 alin. (2) din Codul fiscal."
+	SmallEnterpriseMention   = "Scutit de TVA conform art. 310 din Codul fiscal."
+)
+
+// VATTreatment is the VAT a line is invoiced with and the legal mention the document must carry for it, if any.
+type VATTreatment struct {
+	Regime        VATRegime
+	VATName       string
+	VATPercentage Decimal
+	Mention       string
+}
+
+// VATRules picks the VAT treatment of a line from the client, the company issuing the document and the line.
+type VATRules struct {
+	// VATPayer is set when the company is registered for VAT in Romania. Companies that are not invoice under the
+	// small enterprise exemption.
+	VATPayer bool
+	// OSS is set when the company is registered in the One Stop Shop scheme and charges the VAT of the
+	// destination country on B2C sales to other EU countries.
+	OSS bool
+	// OSSRates are the standard VAT rates of the destination countries by ISO code, e.g. "25.5" for Finland,
+	// required when OSS is set.
+	OSSRates map[string]Decimal
+	// OSSVATNames are the names of the OSS rates in the company VAT nomenclature by ISO code, OSSVATName for
+	// the countries missing from it.
+	OSSVATNames map[string]string
+	// StandardRate is the Romanian standard VAT rate, DefaultStandardVATRate when zero.
+	StandardRate int
+	// ReverseCharge reports whether a line is subject to the domestic reverse charge between VAT payers, e.g.
+	// construction works, cereals or scrap.
+	ReverseCharge func(line *LineItem) bool
+	// DigitalService reports whether a service is a telecommunications, broadcasting or electronically supplied
+	// service, the only services sold to EU individuals under OSS. Other services keep Romanian VAT.
+	DigitalService func(line *LineItem) bool
+}
+
+// Treatment returns the VAT treatment of line sold to client:
+//   - Romanian clients pay Romanian VAT, except VAT payers buying reverse-charge goods and services;
+//   - EU businesses with a valid VAT number buy goods exempt and services under reverse charge;
+//   - EU individuals pay the VAT of their country under OSS for goods and digital services, Romanian VAT
+//     otherwise;
+//   - goods leaving the EU are exported exempt and services to foreign businesses are not taxable in Romania.
+//
+// Lines keeping Romanian VAT keep their VAT name and percentage when set, e.g. for reduced rates.
+func (r VATRules) Treatment(client Client, line *LineItem) (VATTreatment, error) {
+	if !r.VATPayer {
+		return VATTreatment{
+			Regime:  SmallEnterpriseVATRegime,
+			VATName: ExemptWithoutCreditVATName,
+			Mention: SmallEnterpriseMention,
+		}, nil
+	}
+
+	country, err := clientCountry(client)
+	if err != nil {
+		return VATTreatment{}, err
+	}
+
+	service := line.ProductType == ServiceProductType
+
+	switch {
+	case country == "RO":
+		if bool(client.VATPayer) && r.ReverseCharge != nil && r.ReverseCharge(line) {
+			return VATTreatment{
+				Regime:  DomesticReverseChargeVATRegime,
+				VATName: ReverseChargeVATName,
+				Mention: DomesticReverseChargeMention,
+			}, nil
+		}
+
+		return r.domestic(line), nil
+	case isEUCountry(country):
+		if ValidateEUVAT(clientVATNumber(client, country)) == nil {
+			if service {
+				return VATTreatment{
+					Regime:  IntraCommunityServiceVATRegime,
+					VATName: ReverseChargeVATName,
+					Mention: IntraCommunityServiceMention,
+				}, nil
+			}
+
+			return VATTreatment{
+				Regime:  IntraCommunityGoodsVATRegime,
+				VATName: ExemptWithCreditVATName,
+				Mention: IntraCommunityGoodsMention,
+			}, nil
+		}
+
+		if !r.OSS || service && (r.DigitalService == nil || !r.DigitalService(line)) {
+			return r.domestic(line), nil
+		}
+
+		rate, ok := r.OSSRates[country]
+		if !ok {
+			return VATTreatment{}, fmt.Errorf("no OSS rate for %s: %w", country, ErrInvalidArgument)
+		}
+
+		return VATTreatment{Regime: OSSVATRegime, VATName: r.ossVATName(country), VATPercentage: rate}, nil
+	case !service:
+		return VATTreatment{Regime: ExportVATRegime, VATName: ExemptWithCreditVATName, Mention: ExportMention}, nil
+	case client.CIF != "" || bool(client.VATPayer):
+		return VATTreatment{
+			Regime:  NotTaxableServiceVATRegime,
+			VATName: NotTaxableVATName,
+			Mention: NotTaxableServiceMention,
+		}, nil
+	default:
+		return r.domestic(line), nil
+	}
+}
+
+// Apply sets the VAT of every line of rows and returns mentions with the legal mention of each treatment appended
+// once.
+func (r VATRules) Apply(client Client, rows []DocumentRow, mentions string) (string, error) {
+	for i, row := range rows {
+		line, ok := row.(*LineItem)
+		if !ok {
+			continue
+		}
+
+		treatment, err := r.Treatment(client, line)
+		if err != nil {
+			return mentions, fmt.Errorf("products[%d]: %w", i, err)
+		}
+
+		line.VATName = treatment.VATName
+		line.VATPercentage = treatment.VATPercentage
+		mentions = AppendMention(mentions, treatment.Mention)
+	}
+
+	return mentions, nil
+}
+
+// AppendMention appends mention on a new line of mentions unless it is empty or already there.
+func AppendMention(mentions, mention string) string {
+	switch {
+	case mention == "" || strings.Contains(mentions, mention):
+		return mentions
+	case mentions == "":
+		return mention
+	default:
+		return mentions + "\n" + mention
+	}
+}
+
+func (r VATRules) domestic(line *LineItem) VATTreatment {
+	if line.VATName != "" {
+		return VATTreatment{Regime: DomesticVATRegime, VATName: line.VATName, VATPercentage: line.VATPercentage}
+	}
+
+	rate := r.StandardRate
+	if rate == 0 {
+		rate = DefaultStandardVATRate
+	}
+
+	return VATTreatment{Regime: DomesticVATRegime, VATName: StandardVATName, VATPercentage: NewDecimalFromInt(int64(rate))}
+}
+
+func (r VATRules) ossVATName(country string) string {
+	if name := r.OSSVATNames[country]; name != "" {
+		return name
+	}
+
+	return OSSVATName
+}
+
+// clientCountry returns the ISO code of the country of client, taken from the prefix of an EU VAT number first.
+// Two-letter codes missing from ISO 3166-1, such as XK for Kosovo, are kept as they are, being outside the EU.
+func clientCountry(client Client) (string, error) {
+	if hasForeignEUVATPrefix(client.CIF) {
+		return euVATCountry(normalizeFiscalCode(client.CIF)[:2]), nil
+	}
+
+	if client.Country == "" {
+		return "RO", nil
+	}
+
+	if country, ok := LookupCountry(client.Country); ok {
+		return country.Code, nil
+	}
+
+	if code := strings.ToUpper(strings.TrimSpace(client.Country)); countryCodeRegexp.MatchString(code) {
+		return code, nil
+	}
+
+	return "", fmt.Errorf("country %q is unknown: %w", client.Country, ErrInvalidArgument)
+}
+
+// clientVATNumber returns the fiscal code of client prefixed by the VAT prefix of country.
+func clientVATNumber(client Client, country string) string {
+	code := normalizeFiscalCode(client.CIF)
+	if code == "" || hasForeignEUVATPrefix(code) {
+		return code
+	}
+
+	return euVATPrefix(country) + code
+}
+
+func isEUCountry(code string) bool {
+	_, ok := euVATPatterns[euVATPrefix(code)]
+
+	return ok
+}
+
+// euVATPrefix returns the VAT prefix of an EU country, which differs from its ISO code for Greece.
+func euVATPrefix(country string) string {
+	if country == "GR" {
+		return "EL"
+	}
+
+	return country
+}
+
+func euVATCountry(prefix string) string {
+	if prefix == "EL" {
+		return "GR"
+	}
+
+	return prefix
+}
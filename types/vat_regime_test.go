@@ -0,0 +1,268 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestVATRules_Treatment(t *testing.T) {
+	t.Parallel()
+
+	rules := types.VATRules{
+		VATPayer: true,
+		OSS:      true,
+		OSSRates: map[string]types.Decimal{"FR": "20"},
+		ReverseCharge: func(line *types.LineItem) bool {
+			return line.Code == "CONSTR"
+		},
+		DigitalService: func(line *types.LineItem) bool {
+			return line.Code == "SAAS"
+		},
+	}
+
+	var (
+		goods   = &types.LineItem{Name: "Laptop", ProductType: types.MerchandiseProductType}
+		service = &types.LineItem{Name: "Consultanta", ProductType: types.ServiceProductType}
+		digital = &types.LineItem{Name: "Abonament", Code: "SAAS", ProductType: types.ServiceProductType}
+	)
+
+	tests := []struct {
+		name    string
+		rules   types.VATRules
+		client  types.Client
+		line    *types.LineItem
+		want    types.VATTreatment
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "small enterprise",
+			rules:  types.VATRules{},
+			client: types.Client{CIF: "RO37311090"},
+			line:   goods,
+			want: types.VATTreatment{
+				Regime:  types.SmallEnterpriseVATRegime,
+				VATName: types.ExemptWithoutCreditVATName,
+				Mention: types.SmallEnterpriseMention,
+			},
+		},
+		{
+			name:   "domestic standard rate",
+			rules:  rules,
+			client: types.Client{CIF: "RO37311090", VATPayer: true},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Normala", VATPercentage: "21"},
+		},
+		{
+			name:   "domestic keeps reduced rate",
+			rules:  rules,
+			client: types.Client{Name: "Ion Popescu"},
+			line:   &types.LineItem{Name: "Carte", VATName: "Redusa", VATPercentage: "11"},
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Redusa", VATPercentage: "11"},
+		},
+		{
+			name:   "domestic reverse charge",
+			rules:  rules,
+			client: types.Client{CIF: "RO37311090", Country: "România", VATPayer: true},
+			line:   &types.LineItem{Name: "Lucrari", Code: "CONSTR", ProductType: types.ServiceProductType},
+			want: types.VATTreatment{
+				Regime:  types.DomesticReverseChargeVATRegime,
+				VATName: types.ReverseChargeVATName,
+				Mention: types.DomesticReverseChargeMention,
+			},
+		},
+		{
+			name:   "reverse charge needs a vat payer",
+			rules:  rules,
+			client: types.Client{CIF: "RO37311090"},
+			line:   &types.LineItem{Name: "Lucrari", Code: "CONSTR", VATName: "Normala", VATPercentage: "21"},
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Normala", VATPercentage: "21"},
+		},
+		{
+			name:   "intra community goods",
+			rules:  rules,
+			client: types.Client{CIF: "DE123456789", Country: "Germania"},
+			line:   goods,
+			want: types.VATTreatment{
+				Regime:  types.IntraCommunityGoodsVATRegime,
+				VATName: types.ExemptWithCreditVATName,
+				Mention: types.IntraCommunityGoodsMention,
+			},
+		},
+		{
+			name:   "intra community service with greek vat number",
+			rules:  rules,
+			client: types.Client{CIF: "123456789", Country: "Grecia"},
+			line:   service,
+			want: types.VATTreatment{
+				Regime:  types.IntraCommunityServiceVATRegime,
+				VATName: types.ReverseChargeVATName,
+				Mention: types.IntraCommunityServiceMention,
+			},
+		},
+		{
+			name:   "oss",
+			rules:  rules,
+			client: types.Client{Name: "Jean Dupont", Country: "France"},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.OSSVATRegime, VATName: types.OSSVATName, VATPercentage: "20"},
+		},
+		{
+			name: "oss with a configured vat name",
+			rules: types.VATRules{
+				VATPayer:    true,
+				OSS:         true,
+				OSSRates:    map[string]types.Decimal{"FR": "20"},
+				OSSVATNames: map[string]string{"FR": "TVA Franta"},
+			},
+			client: types.Client{Name: "Jean Dupont", Country: "France"},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.OSSVATRegime, VATName: "TVA Franta", VATPercentage: "20"},
+		},
+		{
+			name:   "oss digital service",
+			rules:  rules,
+			client: types.Client{Name: "Jean Dupont", Country: "France"},
+			line:   digital,
+			want:   types.VATTreatment{Regime: types.OSSVATRegime, VATName: types.OSSVATName, VATPercentage: "20"},
+		},
+		{
+			name:   "service to eu individual outside oss",
+			rules:  rules,
+			client: types.Client{Name: "Jean Dupont", Country: "France"},
+			line:   service,
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Normala", VATPercentage: "21"},
+		},
+		{
+			name:   "oss with a fractional rate",
+			rules:  types.VATRules{VATPayer: true, OSS: true, OSSRates: map[string]types.Decimal{"FI": "25.5"}},
+			client: types.Client{Name: "Matti Virtanen", Country: "Finland"},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.OSSVATRegime, VATName: types.OSSVATName, VATPercentage: "25.5"},
+		},
+		{
+			name:   "eu individual without oss",
+			rules:  types.VATRules{VATPayer: true, StandardRate: 19},
+			client: types.Client{Name: "Jean Dupont", Country: "France"},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Normala", VATPercentage: "19"},
+		},
+		{
+			name:   "oss rate missing",
+			rules:  rules,
+			client: types.Client{Name: "Hans Muller", Country: "Austria"},
+			line:   goods,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument) && assert.ErrorContains(t, err, "AT")
+			},
+		},
+		{
+			name:   "export",
+			rules:  rules,
+			client: types.Client{Name: "ACME Inc", Country: "USA"},
+			line:   goods,
+			want: types.VATTreatment{
+				Regime:  types.ExportVATRegime,
+				VATName: types.ExemptWithCreditVATName,
+				Mention: types.ExportMention,
+			},
+		},
+		{
+			name:   "export to any iso country",
+			rules:  rules,
+			client: types.Client{Name: "Sony", Country: "JP"},
+			line:   goods,
+			want: types.VATTreatment{
+				Regime:  types.ExportVATRegime,
+				VATName: types.ExemptWithCreditVATName,
+				Mention: types.ExportMention,
+			},
+		},
+		{
+			name:   "export to a code outside iso",
+			rules:  rules,
+			client: types.Client{Name: "Foo", Country: "xk"},
+			line:   goods,
+			want: types.VATTreatment{
+				Regime:  types.ExportVATRegime,
+				VATName: types.ExemptWithCreditVATName,
+				Mention: types.ExportMention,
+			},
+		},
+		{
+			name:   "oss by greek vat prefix",
+			rules:  types.VATRules{VATPayer: true, OSS: true, OSSRates: map[string]types.Decimal{"GR": "24"}},
+			client: types.Client{Name: "Nikos Papadopoulos", Country: "EL"},
+			line:   goods,
+			want:   types.VATTreatment{Regime: types.OSSVATRegime, VATName: types.OSSVATName, VATPercentage: "24"},
+		},
+		{
+			name:   "service to foreign business",
+			rules:  rules,
+			client: types.Client{CIF: "CHE-123.456.789", Name: "ACME AG", Country: "Elveția"},
+			line:   service,
+			want: types.VATTreatment{
+				Regime:  types.NotTaxableServiceVATRegime,
+				VATName: types.NotTaxableVATName,
+				Mention: types.NotTaxableServiceMention,
+			},
+		},
+		{
+			name:   "service to foreign individual",
+			rules:  rules,
+			client: types.Client{Name: "John Smith", Country: "United States"},
+			line:   service,
+			want:   types.VATTreatment{Regime: types.DomesticVATRegime, VATName: "Normala", VATPercentage: "21"},
+		},
+		{
+			name:   "unknown country",
+			rules:  rules,
+			client: types.Client{Name: "Foo", Country: "Atlantis"},
+			line:   goods,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, types.ErrInvalidArgument)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.rules.Treatment(tt.client, tt.line)
+
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVATRules_Apply(t *testing.T) {
+	t.Parallel()
+
+	rows := []types.DocumentRow{
+		&types.LineItem{Name: "Laptop", VATName: "Normala", VATPercentage: "21"},
+		&types.LineItem{Name: "Mouse"},
+		&types.Discount{Name: "Discount", Discount: "10", DiscountType: types.PercentageDiscountType},
+		&types.LineItem{Name: "Instalare", ProductType: types.ServiceProductType},
+	}
+
+	got, err := types.VATRules{VATPayer: true}.Apply(types.Client{CIF: "DE123456789"}, rows, "Comanda 42")
+	require.NoError(t, err)
+	require.Equal(t, "Comanda 42\n"+types.IntraCommunityGoodsMention+"\n"+types.IntraCommunityServiceMention, got)
+
+	require.Equal(t, &types.LineItem{Name: "Laptop", VATName: types.ExemptWithCreditVATName}, rows[0])
+	require.Equal(t, &types.LineItem{
+		Name:        "Instalare",
+		ProductType: types.ServiceProductType,
+		VATName:     types.ReverseChargeVATName,
+	}, rows[3])
+}
@@ -0,0 +1,233 @@
+package oblio
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+// MaxLimitPerPage is the largest page of invoices GetInvoices returns.
+const MaxLimitPerPage = 100
+
+// documentFields are the fields the invoice, proforma and notice create requests have in common. seriesType is
+// the type of the series the document is numbered from.
+type documentFields struct {
+	cif          string
+	seriesName   string
+	client       types.Client
+	issueDate    types.Date
+	dueDate      types.Date
+	paymentTerms *types.PaymentTerms
+	currency     string
+	exchangeRate types.Decimal
+	products     []types.DocumentRow
+	language     string
+	workStation  string
+	seriesType   types.DocumentType
+	issuerID     string
+}
+
+func (f documentFields) validate() []error {
+	var errs []error
+
+	if f.cif == "" {
+		errs = append(errs, fmt.Errorf("cif is empty: %w", ErrInvalidArgument))
+	} else if err := types.ValidateCIF(f.cif); err != nil {
+		errs = append(errs, err)
+	}
+
+	if f.issuerID != "" {
+		if err := types.ValidateCNP(f.issuerID); err != nil {
+			errs = append(errs, fmt.Errorf("issuerId: %w", err))
+		}
+	}
+
+	if f.seriesName == "" {
+		errs = append(errs, fmt.Errorf("seriesName is empty: %w", ErrInvalidArgument))
+	}
+
+	errs = append(errs, fieldErrors("client", f.client.Validate())...)
+
+	if !f.dueDate.IsZero() && !f.issueDate.IsZero() && f.dueDate.Before(f.issueDate) {
+		errs = append(errs, fmt.Errorf("dueDate is before issueDate: %w", ErrInvalidArgument))
+	}
+
+	errs = append(errs, f.validatePaymentTerms()...)
+
+	errs = append(errs, validateExchangeRate(f.currency, f.exchangeRate)...)
+	errs = append(errs, validateDocumentRows(f.products)...)
+
+	return errs
+}
+
+// validatePaymentTerms checks the payment terms and that a due date set by hand is the one they give.
+func (f documentFields) validatePaymentTerms() []error {
+	if f.paymentTerms == nil {
+		return nil
+	}
+
+	want, err := f.paymentTerms.DueDate(f.issueDateOrToday())
+	if err != nil {
+		return fieldErrors("paymentTerms", err)
+	}
+
+	if !f.dueDate.IsZero() && !f.dueDate.Equal(want) {
+		return []error{fmt.Errorf("dueDate %s does not match the payment terms, due on %s: %w", f.dueDate, want,
+			ErrInvalidArgument)}
+	}
+
+	return nil
+}
+
+// issueDateOrToday returns the issue date, today when it is not set as Oblio then issues the document today.
+func (f documentFields) issueDateOrToday() types.Date {
+	if f.issueDate.IsZero() {
+		return types.Today()
+	}
+
+	return f.issueDate
+}
+
+// withDueDate returns a copy of a valid req with the due date its payment terms give when it has none. Other
+// requests are returned as they are; req itself is never modified.
+func withDueDate[R any, P interface {
+	*R
+	CreateDocumentRequest
+}](req P) P {
+	doc := req.documentFields()
+
+	if doc.paymentTerms == nil || !doc.dueDate.IsZero() {
+		return req
+	}
+
+	due, err := doc.paymentTerms.DueDate(doc.issueDateOrToday())
+	if err != nil {
+		return req
+	}
+
+	filled := P(new(R))
+	*filled = *req
+	filled.setDueDate(due)
+
+	return filled
+}
+
+func formatIssuerID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatInt(id, 10)
+}
+
+// validateExchangeRate checks that an exchange rate is a positive amount given for a foreign currency. A foreign
+// currency without an exchange rate is fine, Oblio uses the BNR rate of the issue date.
+func validateExchangeRate(currency string, exchangeRate types.Decimal) []error {
+	if exchangeRate.IsEmpty() {
+		return nil
+	}
+
+	var errs []error
+
+	if !exchangeRate.Valid() || exchangeRate.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("exchangeRate %q is not a positive amount: %w", exchangeRate, ErrInvalidArgument))
+	}
+
+	if currency == "" || currency == "RON" {
+		errs = append(errs, fmt.Errorf("exchangeRate is set without a foreign currency: %w", ErrInvalidArgument))
+	}
+
+	return errs
+}
+
+// validateDocumentRows checks every row and that discounts refer to a line added before them.
+func validateDocumentRows(rows []types.DocumentRow) []error {
+	var (
+		errs  []error
+		items = make(map[string]struct{})
+		lines int
+	)
+
+	for i, row := range rows {
+		path := fmt.Sprintf("products[%d]", i)
+
+		switch r := row.(type) {
+		case *types.LineItem:
+			if r == nil {
+				errs = append(errs, fmt.Errorf("%s is nil: %w", path, ErrInvalidArgument))
+
+				continue
+			}
+
+			lines++
+			items[r.Name] = struct{}{}
+
+			errs = append(errs, fieldErrors(path, r.Validate())...)
+		case *types.Discount:
+			if r == nil {
+				errs = append(errs, fmt.Errorf("%s is nil: %w", path, ErrInvalidArgument))
+
+				continue
+			}
+
+			errs = append(errs, fieldErrors(path, r.Validate())...)
+
+			if r.DiscountAllAbove {
+				continue
+			}
+
+			if _, ok := items[r.RefItem]; !ok {
+				errs = append(errs, fmt.Errorf(
+					"%s.refItem %q does not match a line above: %w", path, r.RefItem, ErrInvalidArgument))
+			}
+		case nil:
+			errs = append(errs, fmt.Errorf("%s is nil: %w", path, ErrInvalidArgument))
+		}
+	}
+
+	if lines == 0 {
+		errs = append(errs, fmt.Errorf("products has no lines: %w", ErrInvalidArgument))
+	}
+
+	return errs
+}
+
+func validateDocumentRequest(cif, seriesName, number string) []error {
+	var errs []error
+
+	if cif == "" {
+		errs = append(errs, fmt.Errorf("cif is empty: %w", ErrInvalidArgument))
+	}
+
+	if seriesName == "" {
+		errs = append(errs, fmt.Errorf("seriesName is empty: %w", ErrInvalidArgument))
+	}
+
+	if number == "" {
+		errs = append(errs, fmt.Errorf("number is empty: %w", ErrInvalidArgument))
+	}
+
+	return errs
+}
+
+// fieldErrors prefixes every error joined in err with the path of the field it belongs to, e.g.
+// "products[1].price".
+func fieldErrors(path string, err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{fmt.Errorf("%s.%w", path, err)}
+	}
+
+	var errs []error
+
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, fieldErrors(path, err)...)
+	}
+
+	return errs
+}
@@ -0,0 +1,251 @@
+package oblio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api"
+	"github.com/vcraescu/go-oblio-api/obliotest"
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+func TestRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client = types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"}
+		item   = &types.LineItem{Name: "Abonament", Price: "100", Quantity: "1"}
+	)
+
+	tests := []struct {
+		name      string
+		req       oblio.Validator
+		wantPaths []string
+	}{
+		{
+			name: "valid invoice",
+			req: &oblio.CreateInvoiceRequest{
+				CIF:          "RO12345674",
+				SeriesName:   "FCT",
+				Client:       client,
+				IssueDate:    types.NewDate(2024, 1, 15),
+				DueDate:      types.NewDate(2024, 2, 14),
+				Currency:     "EUR",
+				ExchangeRate: "4.9741",
+				Products: []types.DocumentRow{
+					item,
+					&types.Discount{
+						Name:         "Reducere",
+						RefItem:      "Abonament",
+						Discount:     "10",
+						DiscountType: types.PercentageDiscountType,
+					},
+				},
+				Collect: types.Collect{Type: types.ReceiptCollectType, SeriesName: "CHT"},
+			},
+		},
+		{
+			name: "invalid invoice",
+			req: &oblio.CreateInvoiceRequest{
+				IssueDate:    types.NewDate(2024, 1, 15),
+				DueDate:      types.NewDate(2024, 1, 1),
+				ExchangeRate: "4.97",
+				Products: []types.DocumentRow{
+					&types.Discount{Name: "Reducere", RefItem: "Missing", Discount: "10", DiscountType: "x"},
+					&types.LineItem{Price: "-1"},
+				},
+				Collect: types.Collect{Type: types.PaymentOrderCollectType},
+			},
+			wantPaths: []string{
+				"cif is empty",
+				"seriesName is empty",
+				"client.cif and name are empty",
+				"dueDate is before issueDate",
+				"exchangeRate is set without a foreign currency",
+				"products[0].discountType",
+				`products[0].refItem "Missing"`,
+				"products[1].name is empty",
+				"products[1].price",
+				"collect.documentNumber is empty",
+			},
+		},
+		{
+			name: "invalid fiscal codes",
+			req: &oblio.CreateNoticeRequest{
+				CIF:        "RO12345678",
+				SeriesName: "AVZ",
+				Client:     types.Client{CIF: "DE12345"},
+				IssuerID:   1820913326911,
+				Products:   []types.DocumentRow{item},
+			},
+			wantPaths: []string{`cif "RO12345678"`, "client.cif", "issuerId"},
+		},
+		{
+			name: "proforma without lines",
+			req: &oblio.CreateProformaRequest{
+				CIF:        "RO12345674",
+				SeriesName: "PRF",
+				Client:     client,
+				Products: []types.DocumentRow{
+					&types.Discount{
+						Name:             "Reducere",
+						Discount:         "5",
+						DiscountType:     types.FlatDiscountType,
+						DiscountAllAbove: true,
+					},
+				},
+			},
+			wantPaths: []string{"products has no lines"},
+		},
+		{
+			name: "due date not matching the payment terms",
+			req: &oblio.CreateInvoiceRequest{
+				CIF:          "RO12345674",
+				SeriesName:   "FCT",
+				Client:       client,
+				IssueDate:    types.NewDate(2024, 1, 15),
+				DueDate:      types.NewDate(2024, 1, 20),
+				PaymentTerms: &types.PaymentTerms{Kind: types.NetPaymentTerms, Days: 30},
+				Products:     []types.DocumentRow{item},
+			},
+			wantPaths: []string{"dueDate 2024-01-20 does not match the payment terms, due on 2024-02-14"},
+		},
+		{
+			name: "invalid payment terms",
+			req: &oblio.CreateProformaRequest{
+				CIF:          "RO12345674",
+				SeriesName:   "PRF",
+				Client:       client,
+				PaymentTerms: &types.PaymentTerms{Kind: types.DayOfNextMonthPaymentTerms, Day: 32},
+				Products:     []types.DocumentRow{item},
+			},
+			wantPaths: []string{"paymentTerms.day"},
+		},
+		{
+			name: "nil rows",
+			req: &oblio.CreateInvoiceRequest{
+				CIF:        "RO12345674",
+				SeriesName: "FCT",
+				Client:     client,
+				Products:   []types.DocumentRow{item, nil, (*types.LineItem)(nil), (*types.Discount)(nil)},
+			},
+			wantPaths: []string{"products[1] is nil", "products[2] is nil", "products[3] is nil"},
+		},
+		{
+			name: "valid notice",
+			req: &oblio.CreateNoticeRequest{
+				CIF:        "RO12345674",
+				SeriesName: "AVZ",
+				Client:     types.Client{Name: "Ion Popescu"},
+				Products:   []types.DocumentRow{item},
+			},
+		},
+		{
+			name: "collect",
+			req: &oblio.CollectRequest{
+				CIF:        "RO12345674",
+				SeriesName: "FCT",
+				Collects: []types.Collect{
+					{Type: types.CardCollectType, Value: "10"},
+					{Type: "Barter", Value: "0"},
+				},
+			},
+			wantPaths: []string{"number is empty", "collects[1].type", "collects[1].value"},
+		},
+		{
+			name:      "document",
+			req:       &oblio.DocumentRequest{CIF: "RO12345674"},
+			wantPaths: []string{"seriesName is empty", "number is empty"},
+		},
+		{
+			name: "invoices list",
+			req: &oblio.GetInvoicesRequest{
+				CIF:          "RO12345674",
+				IssuedAfter:  types.NewDate(2024, 2, 1),
+				IssuedBefore: types.NewDate(2024, 1, 1),
+				OrderBy:      "total",
+				OrderDir:     "UP",
+				LimitPerPage: 500,
+				Offset:       -1,
+			},
+			wantPaths: []string{"issuedBefore", "orderBy", "orderDir", "limitPerPage", "offset"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.req.Validate()
+
+			if len(tt.wantPaths) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+
+			for _, want := range tt.wantPaths {
+				require.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestClient_StrictEnums(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = context.Background()
+		req = &oblio.CreateInvoiceRequest{
+			CIF:        obliotest.DefaultCIF,
+			SeriesName: "FCT",
+			Client:     types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+			},
+			Collect: types.Collect{Type: "Card bancar"},
+		}
+	)
+
+	srv := obliotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	_, err := oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL), oblio.WithStrictEnums()).
+		CreateInvoice(ctx, req)
+	require.ErrorIs(t, err, oblio.ErrInvalidArgument)
+	require.ErrorContains(t, err, `collect.type "Card bancar" is unknown`)
+	require.Empty(t, srv.Invoices(obliotest.DefaultCIF))
+}
+
+func TestClient_CreateInvoice_PaymentTerms(t *testing.T) {
+	t.Parallel()
+
+	srv := obliotest.NewServer()
+	t.Cleanup(srv.Close)
+
+	var (
+		client = oblio.NewClient("client-id", "client-secret", oblio.WithBaseURL(srv.URL))
+		terms  = types.BusinessDays(5)
+		req    = &oblio.CreateInvoiceRequest{
+			CIF:          obliotest.DefaultCIF,
+			SeriesName:   "FCT",
+			Client:       types.Client{CIF: "RO37311090", Name: "OBLIO SOFTWARE SRL"},
+			IssueDate:    types.NewDate(2024, 4, 29),
+			PaymentTerms: &terms,
+			Products: []types.DocumentRow{
+				&types.LineItem{Name: "Carte", Price: "100", VATName: "Normala", VATPercentage: "19"},
+			},
+		}
+	)
+
+	_, err := client.CreateInvoice(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, req.DueDate.IsZero())
+
+	invoices := srv.Invoices(obliotest.DefaultCIF)
+	require.Len(t, invoices, 1)
+	require.Equal(t, types.NewDate(2024, 5, 9), invoices[0].DueDate)
+}
@@ -0,0 +1,206 @@
+// Package vies checks EU VAT numbers in the VAT Information Exchange System (VIES) of the European Commission.
+package vies
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vcraescu/go-oblio-api/types"
+)
+
+const (
+	// URL is the endpoint of the VIES REST API checking a VAT number.
+	URL = "https://ec.europa.eu/taxation_customs/vies/rest-api/check-vat-number"
+
+	DefaultCacheTTL = 24 * time.Hour
+)
+
+// ErrUnavailable is returned when VIES or the service of the member state cannot answer, the check having to be
+// retried later.
+var ErrUnavailable = errors.New("vies unavailable")
+
+var _ types.VATChecker = (*Checker)(nil)
+
+type cacheEntry struct {
+	consultation types.VATConsultation
+	expiresAt    time.Time
+}
+
+// Checker checks VAT numbers in VIES and keeps the consultations, valid or not, for the cache TTL. It is safe
+// for concurrent use.
+type Checker struct {
+	client    *http.Client
+	url       string
+	requester string
+	cacheTTL  time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	cache     map[string]cacheEntry
+}
+
+func NewChecker(opts ...Option) *Checker {
+	options := newOptions(opts)
+
+	return &Checker{
+		client:    options.client,
+		url:       options.url,
+		requester: options.requester,
+		cacheTTL:  options.cacheTTL,
+		now:       time.Now,
+		cache:     make(map[string]cacheEntry),
+	}
+}
+
+func (c *Checker) CheckVAT(ctx context.Context, vatNumber string) (types.VATConsultation, error) {
+	if err := types.ValidateEUVAT(vatNumber); err != nil {
+		return types.VATConsultation{}, fmt.Errorf("vatNumber: %w", err)
+	}
+
+	key := strings.ToUpper(strings.Join(strings.Fields(vatNumber), ""))
+
+	if consultation, ok := c.cached(key); ok {
+		return consultation, nil
+	}
+
+	consultation, err := c.check(ctx, key)
+	if err != nil {
+		return types.VATConsultation{}, err
+	}
+
+	if c.cacheTTL > 0 {
+		c.mu.Lock()
+		c.cache[key] = cacheEntry{consultation: consultation, expiresAt: c.now().Add(c.cacheTTL)}
+		c.mu.Unlock()
+	}
+
+	return consultation, nil
+}
+
+func (c *Checker) cached(key string) (types.VATConsultation, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		delete(c.cache, key)
+
+		return types.VATConsultation{}, false
+	}
+
+	return entry.consultation, true
+}
+
+func (c *Checker) check(ctx context.Context, vatNumber string) (types.VATConsultation, error) {
+	req := request{CountryCode: vatNumber[:2], VATNumber: vatNumber[2:]}
+
+	if requester := strings.ToUpper(strings.Join(strings.Fields(c.requester), "")); len(requester) > 2 {
+		req.RequesterCountryCode = requester[:2]
+		req.RequesterNumber = requester[2:]
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return types.VATConsultation{}, fmt.Errorf("marshal: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return types.VATConsultation{}, fmt.Errorf("new request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := c.client.Do(httpReq)
+	if err != nil {
+		return types.VATConsultation{}, err
+	}
+	defer httpResp.Body.Close()
+
+	resp := &response{}
+
+	err = json.NewDecoder(httpResp.Body).Decode(resp)
+
+	switch {
+	case err == nil && len(resp.ErrorWrappers) > 0:
+		return types.VATConsultation{}, resp.err()
+	case httpResp.StatusCode != http.StatusOK:
+		return types.VATConsultation{}, fmt.Errorf("unexpected status: %s", httpResp.Status)
+	case err != nil:
+		return types.VATConsultation{}, fmt.Errorf("decode: %w", err)
+	}
+
+	return types.VATConsultation{
+		CountryCode:       resp.CountryCode,
+		VATNumber:         resp.VATNumber,
+		Valid:             resp.Valid,
+		Name:              undisclosed(resp.Name),
+		Address:           undisclosed(resp.Address),
+		RequestDate:       resp.RequestDate,
+		RequestIdentifier: resp.RequestIdentifier,
+	}, nil
+}
+
+type request struct {
+	CountryCode          string `json:"countryCode"`
+	VATNumber            string `json:"vatNumber"`
+	RequesterCountryCode string `json:"requesterMemberStateCode,omitempty"`
+	RequesterNumber      string `json:"requesterNumber,omitempty"`
+}
+
+type response struct {
+	CountryCode       string    `json:"countryCode"`
+	VATNumber         string    `json:"vatNumber"`
+	RequestDate       time.Time `json:"requestDate"`
+	Valid             bool      `json:"valid"`
+	RequestIdentifier string    `json:"requestIdentifier"`
+	Name              string    `json:"name"`
+	Address           string    `json:"address"`
+	ErrorWrappers     []struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	} `json:"errorWrappers"`
+}
+
+// unavailableErrors are the VIES errors of a temporary failure.
+var unavailableErrors = map[string]bool{
+	"SERVICE_UNAVAILABLE":       true,
+	"MS_UNAVAILABLE":            true,
+	"TIMEOUT":                   true,
+	"GLOBAL_MAX_CONCURRENT_REQ": true,
+	"MS_MAX_CONCURRENT_REQ":     true,
+}
+
+func (r *response) err() error {
+	errs := make([]error, 0, len(r.ErrorWrappers))
+
+	for _, e := range r.ErrorWrappers {
+		err := fmt.Errorf("vies error %s", e.Error)
+		if e.Message != "" {
+			err = fmt.Errorf("vies error %s: %s", e.Error, e.Message)
+		}
+
+		if unavailableErrors[e.Error] {
+			err = fmt.Errorf("%w: %w", err, ErrUnavailable)
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func undisclosed(s string) string {
+	if s = strings.TrimSpace(s); s == "---" {
+		return ""
+	}
+
+	return s
+}
@@ -0,0 +1,160 @@
+package vies_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vcraescu/go-oblio-api/types"
+	"github.com/vcraescu/go-oblio-api/vies"
+)
+
+func newServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		var req map[string]string
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		switch req["countryCode"] + req["vatNumber"] {
+		case "DE123456789":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"countryCode":       "DE",
+				"vatNumber":         "123456789",
+				"requestDate":       "2024-05-07T10:11:12.345Z",
+				"valid":             true,
+				"requestIdentifier": "WAPIAAAAY" + req["requesterMemberStateCode"] + req["requesterNumber"],
+				"name":              "---",
+				"address":           "---",
+			})
+		case "FR40303265045":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"countryCode": "FR",
+				"vatNumber":   "40303265045",
+				"requestDate": "2024-05-07T10:11:12.345Z",
+				"valid":       true,
+				"name":        "SA ODIGEO",
+				"address":     "1 RUE DE LA PAIX\n75002 PARIS",
+			})
+		case "IT12345678901":
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"actionSucceed": false,
+				"errorWrappers": []map[string]string{{"error": "MS_UNAVAILABLE"}},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"countryCode": req["countryCode"],
+				"vatNumber":   req["vatNumber"],
+				"requestDate": "2024-05-07T10:11:12.345Z",
+				"valid":       false,
+			})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestChecker_CheckVAT(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	requestDate := time.Date(2024, 5, 7, 10, 11, 12, 345000000, time.UTC)
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		srv := newServer(t, &requests)
+		checker := vies.NewChecker(vies.WithURL(srv.URL), vies.WithRequester("RO37311090"))
+
+		got, err := checker.CheckVAT(ctx, "fr 40303265045")
+		require.NoError(t, err)
+		require.Equal(t, types.VATConsultation{
+			CountryCode: "FR",
+			VATNumber:   "40303265045",
+			Valid:       true,
+			Name:        "SA ODIGEO",
+			Address:     "1 RUE DE LA PAIX\n75002 PARIS",
+			RequestDate: requestDate,
+		}, got)
+
+		got, err = checker.CheckVAT(ctx, "DE123456789")
+		require.NoError(t, err)
+		require.Equal(t, "WAPIAAAAYRO37311090", got.RequestIdentifier)
+		require.Empty(t, got.Name)
+		require.Empty(t, got.Address)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		srv := newServer(t, &requests)
+		checker := vies.NewChecker(vies.WithURL(srv.URL))
+
+		got, err := checker.CheckVAT(ctx, "DE999999999")
+		require.NoError(t, err)
+		require.False(t, got.Valid)
+
+		_, err = checker.CheckVAT(ctx, "DE12")
+		require.ErrorIs(t, err, types.ErrInvalidArgument)
+		require.EqualValues(t, 1, requests.Load())
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		srv := newServer(t, &requests)
+		checker := vies.NewChecker(vies.WithURL(srv.URL))
+
+		_, err := checker.CheckVAT(ctx, "IT12345678901")
+		require.ErrorIs(t, err, vies.ErrUnavailable)
+		require.ErrorContains(t, err, "MS_UNAVAILABLE")
+
+		_, err = checker.CheckVAT(ctx, "IT12345678901")
+		require.Error(t, err)
+		require.EqualValues(t, 2, requests.Load())
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		t.Parallel()
+
+		var requests atomic.Int32
+
+		srv := newServer(t, &requests)
+		cached := vies.NewChecker(vies.WithURL(srv.URL))
+		uncached := vies.NewChecker(vies.WithURL(srv.URL), vies.WithCacheTTL(0))
+
+		for range 3 {
+			_, err := cached.CheckVAT(ctx, "DE123456789")
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 1, requests.Load())
+
+		for range 2 {
+			_, err := uncached.CheckVAT(ctx, "DE123456789")
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 3, requests.Load())
+	})
+}
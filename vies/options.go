@@ -0,0 +1,67 @@
+package vies
+
+import (
+	"net/http"
+	"time"
+)
+
+type options struct {
+	client    *http.Client
+	url       string
+	requester string
+	cacheTTL  time.Duration
+}
+
+type Option interface {
+	apply(opts *options)
+}
+
+var _ Option = optionFunc(nil)
+
+type optionFunc func(opts *options)
+
+func (fn optionFunc) apply(opts *options) {
+	fn(opts)
+}
+
+func WithClient(client *http.Client) Option {
+	return optionFunc(func(opts *options) {
+		opts.client = client
+	})
+}
+
+// WithURL sends the requests to url instead of URL, e.g. to a local stub.
+func WithURL(url string) Option {
+	return optionFunc(func(opts *options) {
+		opts.url = url
+	})
+}
+
+// WithRequester sends the VAT number of the company making the checks, for which VIES returns a consultation
+// number.
+func WithRequester(vatNumber string) Option {
+	return optionFunc(func(opts *options) {
+		opts.requester = vatNumber
+	})
+}
+
+// WithCacheTTL keeps the consultations for ttl, DefaultCacheTTL by default. Zero disables the cache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return optionFunc(func(opts *options) {
+		opts.cacheTTL = ttl
+	})
+}
+
+func newOptions(opts []Option) *options {
+	options := &options{
+		client:   http.DefaultClient,
+		url:      URL,
+		cacheTTL: DefaultCacheTTL,
+	}
+
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	return options
+}